@@ -9,7 +9,10 @@ import { StaticView } from './pages/staticView';
 export const App = () => {
     const [gameProps, setGameProps] = React.useState<GameProps | undefined>();
     const leave = React.useCallback(() => setGameProps(undefined), []);
-    const onLogin = React.useCallback((roomID, nickname) => setGameProps({ roomID, nickname, leave }), [leave]);
+    const onLogin = React.useCallback(
+        (roomID, nickname, spectator, access) => setGameProps({ roomID, ...access, nickname, spectator, leave }),
+        [leave]
+    );
 
     if (process.env.NODE_ENV === 'development') {
         const query = querystring.parse(window.location.search.substring(1));
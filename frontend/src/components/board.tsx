@@ -68,6 +68,17 @@ const useTileStyles = makeStyles((theme: Theme) =>
                 lineHeight: '1rem',
             },
         },
+        votes: {
+            position: 'absolute',
+            top: '0.25rem',
+            right: '0.25rem',
+            minWidth: '1.5rem',
+            borderRadius: '0.75rem',
+            padding: '0 0.25rem',
+            color: 'white',
+            backgroundColor: grey[800],
+            pointerEvents: 'none',
+        },
         explosionWrapper: {
             zIndex: 100,
             position: 'absolute',
@@ -132,6 +143,11 @@ const Tile = React.memo(function Tile({ row, col, onClick, tile, spymaster, myTu
                     {tile.word}
                 </Typography>
             </Button>
+            {tile.votes && tile.votes.length > 0 ? (
+                <Typography variant="caption" className={classes.votes}>
+                    {tile.votes.length}
+                </Typography>
+            ) : null}
             {explode ? (
                 <div className={classes.explosionWrapper}>
                     <div className={classes.explosion}>
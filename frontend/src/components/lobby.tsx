@@ -0,0 +1,84 @@
+import { createStyles, List, ListItem, ListItemText, makeStyles, Theme, Typography } from '@material-ui/core';
+import * as React from 'react';
+
+import { version } from '../metadata.json';
+import { LobbyRoom, RoomsResponse } from '../protocol';
+
+const refreshInterval = 10000;
+
+const useStyles = makeStyles((theme: Theme) =>
+    createStyles({
+        root: {
+            marginTop: theme.spacing(2),
+            textAlign: 'left',
+        },
+        list: {
+            maxHeight: '40vh',
+            overflow: 'auto',
+        },
+    })
+);
+
+function describe(room: LobbyRoom): string {
+    const parts = [`${room.players} playing (${room.teams.join(' vs ')})`];
+    if (room.spectators > 0) {
+        parts.push(`${room.spectators} watching`);
+    }
+    parts.push(room.inProgress ? 'In progress' : 'Not started');
+    if (room.mode === 'duet') {
+        parts.push('Duet');
+    }
+    parts.push(room.packs.join(', '));
+    return parts.join(' · ');
+}
+
+export interface LobbyProps {
+    onJoin: (roomID: string) => void;
+}
+
+// Lists the public rooms, which anyone may join without a password.
+export const Lobby = (props: LobbyProps) => {
+    const classes = useStyles();
+    const [rooms, setRooms] = React.useState<readonly LobbyRoom[]>([]);
+
+    React.useEffect(() => {
+        let cancelled = false;
+
+        const load = async () => {
+            try {
+                const response = await fetch('/api/rooms', { headers: { 'X-CODIES-VERSION': version } });
+                const resp = RoomsResponse.parse(await response.json());
+                if (!cancelled) {
+                    setRooms(resp.rooms);
+                }
+                // eslint-disable-next-line no-empty
+            } catch {}
+        };
+
+        load();
+        const interval = window.setInterval(load, refreshInterval);
+        return () => {
+            cancelled = true;
+            window.clearInterval(interval);
+        };
+    }, []);
+
+    if (rooms.length === 0) {
+        return null;
+    }
+
+    return (
+        <div className={classes.root}>
+            <Typography variant="h6" component="h2">
+                Public games
+            </Typography>
+            <List dense className={classes.list}>
+                {rooms.map((room) => (
+                    <ListItem button key={room.id} onClick={() => props.onJoin(room.id)}>
+                        <ListItemText primary={room.name} secondary={describe(room)} />
+                    </ListItem>
+                ))}
+            </List>
+        </div>
+    );
+};
@@ -1,4 +1,13 @@
-import { Button, ButtonGroup, createStyles, makeStyles, TextField, Theme } from '@material-ui/core';
+import {
+    Button,
+    ButtonGroup,
+    Checkbox,
+    createStyles,
+    FormControlLabel,
+    makeStyles,
+    TextField,
+    Theme,
+} from '@material-ui/core';
 import * as React from 'react';
 import { Controller, useForm } from 'react-hook-form';
 
@@ -9,6 +18,8 @@ export interface LoginFormData {
     roomName: string;
     roomPass: string;
     create: boolean;
+    public: boolean;
+    spectator: boolean;
 }
 
 const formName = nameofFactory<LoginFormData>();
@@ -33,8 +44,10 @@ export interface LoginFormProps {
 // Sync rules with protocol.go.
 export function LoginForm(props: LoginFormProps) {
     const classes = useStyles();
-    const { control, handleSubmit, errors, setValue, register } = useForm<LoginFormData>({});
+    const { control, handleSubmit, errors, setValue, register, watch } = useForm<LoginFormData>({});
+    const isPublic = watch(formName('public'), false);
     React.useEffect(() => register({ name: formName('create') }), [register]);
+    React.useEffect(() => register({ name: formName('spectator') }), [register]);
     const doSubmit = handleSubmit(props.onSubmit);
 
     return (
@@ -78,7 +91,7 @@ export function LoginForm(props: LoginFormProps) {
                         />
                     </div>
 
-                    <div className={classes.padBottom}>
+                    <div>
                         <Controller
                             control={control}
                             as={TextField}
@@ -87,12 +100,28 @@ export function LoginForm(props: LoginFormProps) {
                             defaultValue=""
                             type="password"
                             error={!!errors.roomPass}
-                            rules={{ required: true, minLength: 1 }}
+                            rules={{ required: !isPublic }}
                             fullWidth={true}
                             inputProps={noComplete}
                             InputLabelProps={{ classes: { focused: classes.label } }}
                         />
                     </div>
+
+                    <div className={classes.padBottom}>
+                        <Controller
+                            control={control}
+                            name={formName('public')}
+                            defaultValue={false}
+                            render={({ value, onChange }) => (
+                                <FormControlLabel
+                                    control={
+                                        <Checkbox checked={value} onChange={(e) => onChange(e.target.checked)} />
+                                    }
+                                    label="Public game (listed below; no password needed)"
+                                />
+                            )}
+                        />
+                    </div>
                 </>
             )}
 
@@ -108,17 +137,30 @@ export function LoginForm(props: LoginFormProps) {
                         type="submit"
                         onClick={() => {
                             setValue(formName('create'), false);
+                            setValue(formName('spectator'), false);
                             doSubmit();
                         }}
                     >
                         Join game
                     </Button>
 
+                    <Button
+                        type="button"
+                        onClick={() => {
+                            setValue(formName('create'), false);
+                            setValue(formName('spectator'), true);
+                            doSubmit();
+                        }}
+                    >
+                        Watch
+                    </Button>
+
                     {props.existingRoom ? null : (
                         <Button
                             type="button"
                             onClick={() => {
                                 setValue(formName('create'), true);
+                                setValue(formName('spectator'), false);
                                 doSubmit();
                             }}
                         >
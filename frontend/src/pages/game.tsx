@@ -1,3 +1,4 @@
+import { Snackbar } from '@material-ui/core';
 import { fail } from 'assert';
 import * as React from 'react';
 import useWebSocket from 'react-use-websocket';
@@ -6,7 +7,22 @@ import { DeepReadonly } from 'ts-essentials';
 import { assertIsDefined, assertNever, noop, reloadOutdatedPage, websocketUrl } from '../common';
 import { useServerTime } from '../hooks';
 import { version as codiesVersion } from '../metadata.json';
-import { ClientNote, PartialClientNote, ServerNote, State, StatePlayer, TimeResponse, WordPack } from '../protocol';
+import {
+    ChatMessage,
+    ClientNote,
+    CloseEventData,
+    GameMode,
+    InviteResponse,
+    PartialClientNote,
+    ResultCode,
+    ServerNote,
+    State,
+    StatePlayer,
+    TimeResponse,
+    UndoRule,
+    WordPack,
+} from '../protocol';
+import { applyPatch } from '../protocol/patch';
 import { GameView, Sender } from './gameView';
 import { Loading } from './loading';
 
@@ -23,10 +39,11 @@ function useSender(dispatch: (action: PartialClientNote) => void): Sender {
                         col,
                     },
                 }),
-            newGame: () => dispatch({ method: 'newGame', params: {} }),
+            newGame: (seed?: string) => dispatch({ method: 'newGame', params: { seed } }),
             endTurn: () => dispatch({ method: 'endTurn', params: {} }),
             changeNickname: (nickname: string) => dispatch({ method: 'changeNickname', params: { nickname } }),
             changeRole: (spymaster: boolean) => dispatch({ method: 'changeRole', params: { spymaster } }),
+            spectate: () => dispatch({ method: 'changeRole', params: { spymaster: false, spectator: true } }),
             changeTeam: (team: number) => dispatch({ method: 'changeTeam', params: { team } }),
             randomizeTeams: () => dispatch({ method: 'randomizeTeams', params: {} }),
             changePack: (num: number, enable: boolean) => dispatch({ method: 'changePack', params: { num, enable } }),
@@ -35,6 +52,26 @@ function useSender(dispatch: (action: PartialClientNote) => void): Sender {
             addPacks: (packs: WordPack[]) => dispatch({ method: 'addPacks', params: { packs } }),
             removePack: (num: number) => dispatch({ method: 'removePack', params: { num } }),
             changeHideBomb: (hideBomb: boolean) => dispatch({ method: 'changeHideBomb', params: { hideBomb } }),
+            changeNumTeams: (numTeams: number) => dispatch({ method: 'changeNumTeams', params: { numTeams } }),
+            changeBoardSize: (rows: number, cols: number) =>
+                dispatch({ method: 'changeBoardSize', params: { rows, cols } }),
+            changeMode: (mode: GameMode) => dispatch({ method: 'changeMode', params: { mode } }),
+            giveClue: (word: string, count: number, unlimited: boolean) =>
+                dispatch({ method: 'giveClue', params: { word, count, unlimited } }),
+            undo: () => dispatch({ method: 'undo', params: {} }),
+            changeUndoRule: (rule: UndoRule) => dispatch({ method: 'changeUndoRule', params: { rule } }),
+            changeRevealShare: (share: number) => dispatch({ method: 'changeRevealShare', params: { share } }),
+            changeHost: (playerID: string) => dispatch({ method: 'changeHost', params: { playerID } }),
+            kick: (playerID: string) => dispatch({ method: 'kick', params: { playerID } }),
+            ban: (playerID: string, nickname: string) => dispatch({ method: 'ban', params: { playerID, nickname } }),
+            changeLocked: (locked: boolean) => dispatch({ method: 'changeLocked', params: { locked } }),
+            changeHostOnly: (hostOnly: boolean) => dispatch({ method: 'changeHostOnly', params: { hostOnly } }),
+            revokeInvites: () => dispatch({ method: 'revokeInvites', params: {} }),
+            chat: (text: string, team: boolean) => dispatch({ method: 'chat', params: { text, team } }),
+            changeHideTeamChat: (hideTeamChat: boolean) =>
+                dispatch({ method: 'changeHideTeamChat', params: { hideTeamChat } }),
+            changeStreamSafe: (streamSafe: boolean) =>
+                dispatch({ method: 'changeStreamSafe', params: { streamSafe } }),
         };
     }, [dispatch]);
 }
@@ -45,6 +82,14 @@ function usePlayer(state?: State): { pState: StatePlayer; pTeam: number } | unde
             return undefined;
         }
 
+        if (state.spectator) {
+            const pState = state.roomState.spectators.find((p) => p.playerID === state.playerID);
+            if (pState) {
+                return { pState, pTeam: -1 };
+            }
+            fail('Spectator not found');
+        }
+
         for (let i = 0; i < state.roomState.teams.length; i++) {
             const pState = state.roomState.teams[i].find((p) => p.playerID === state.playerID);
             if (pState) {
@@ -58,42 +103,233 @@ function usePlayer(state?: State): { pState: StatePlayer; pTeam: number } | unde
 
 const reconnectAttempts = 2;
 
-function useWS(roomID: string, nickname: string, dead: () => void, onOpen: () => void) {
+// Matches the server's scrollback.
+const maxChatMessages = 100;
+
+// Adds new messages to the chat; messages already seen before a reconnect are skipped.
+function appendChat(chat: ChatMessage[], messages: readonly ChatMessage[]): ChatMessage[] {
+    const lastID = chat.length > 0 ? chat[chat.length - 1].id : 0;
+    const added = messages.filter((m) => m.id > lastID);
+    if (added.length === 0) {
+        return chat;
+    }
+    return [...chat, ...added].slice(-maxChatMessages);
+}
+
+// Close codes for connections refused or removed by the host: banned, kicked, locked, and no key or bad invite.
+const refusedCodes = [4403, 4410, 4423, 4401];
+
+// Reconnect tokens are kept per tab, so that each tab stays its own player.
+function reconnectTokenKey(roomID: string) {
+    return `codies-token-${roomID}`;
+}
+
+// Set once WebSockets have failed, so that later loads use server-sent events straight away.
+const eventsTransportKey = 'codies-events-transport';
+
+// closed handles the server closing the connection, returning true if the
+// client shouldn't reconnect.
+function closed(code: number, reason: string, dead: () => void): boolean {
+    if (code === 4418) {
+        reloadOutdatedPage();
+    }
+
+    if (refusedCodes.includes(code)) {
+        window.alert(`Left the room: ${reason}.`);
+        dead();
+        return true;
+    }
+
+    return false;
+}
+
+function useWS(
+    roomID: string,
+    roomKey: string | undefined,
+    invite: string | undefined,
+    nickname: string,
+    spectator: boolean,
+    connect: boolean,
+    dead: () => void,
+    onOpen: () => void,
+    fallback: () => void
+) {
     const didUnmount = React.useRef(false);
     const retry = React.useRef(0);
+    const refused = React.useRef(false);
+    const opened = React.useRef(false);
+    const token = sessionStorage.getItem(reconnectTokenKey(roomID)) ?? '';
+
+    return useWebSocket(
+        socketUrl,
+        {
+            // The names here matter; explicitly naming them so that renaming
+            // these variables doesn't change the actual wire names.
+            //
+            // X-CODIES-VERSION would be cleaner, but the WS hook doesn't
+            // support anything but query params.
+            queryParams: {
+                roomID: roomID,
+                key: roomKey ?? '',
+                invite: invite ?? '',
+                nickname: nickname,
+                token: token,
+                spectator: spectator ? 'true' : 'false',
+                patches: 'true',
+                codiesVersion: codiesVersion,
+            },
+            reconnectAttempts,
+            onMessage: () => {
+                retry.current = 0;
+            },
+            onOpen: () => {
+                opened.current = true;
+                onOpen();
+            },
+            onClose: (e: CloseEvent) => {
+                // The upgrade never succeeded; a proxy may be blocking WebSockets.
+                if (!opened.current && e.code === 1006) {
+                    refused.current = true;
+                    fallback();
+                    return;
+                }
 
-    return useWebSocket(socketUrl, {
-        // The names here matter; explicitly naming them so that renaming
-        // these variables doesn't change the actual wire names.
-        //
-        // X-CODIES-VERSION would be cleaner, but the WS hook doesn't
-        // support anything but query params.
-        queryParams: { roomID: roomID, nickname: nickname, codiesVersion: codiesVersion },
-        reconnectAttempts,
-        onMessage: () => {
-            retry.current = 0;
-        },
-        onOpen,
-        onClose: (e: CloseEvent) => {
-            if (e.code === 4418) {
-                reloadOutdatedPage();
-            }
+                refused.current = closed(e.code, e.reason, dead);
+            },
+            shouldReconnect: () => {
+                if (didUnmount.current || refused.current) {
+                    return false;
+                }
+
+                retry.current++;
+
+                if (retry.current >= reconnectAttempts) {
+                    dead();
+                    return false;
+                }
+
+                return true;
+            },
         },
-        shouldReconnect: () => {
-            if (didUnmount.current) {
-                return false;
-            }
+        connect
+    );
+}
 
-            retry.current++;
+// useEvents is the fallback for useWS where WebSockets are blocked. Notes
+// arrive as server-sent events, and are sent as requests with the reconnect
+// token, which identifies the player.
+function useEvents(
+    roomID: string,
+    roomKey: string | undefined,
+    invite: string | undefined,
+    nickname: string,
+    spectator: boolean,
+    connect: boolean,
+    dead: () => void,
+    onOpen: () => void
+) {
+    const [lastJsonMessage, setLastJsonMessage] = React.useState<unknown>(null);
+
+    // Only read when (re)connecting, like useWS's query params.
+    const latest = React.useRef({ nickname, spectator, dead, onOpen });
+    latest.current = { nickname, spectator, dead, onOpen };
 
-            if (retry.current >= reconnectAttempts) {
-                dead();
-                return false;
+    React.useEffect(() => {
+        if (!connect) {
+            return;
+        }
+
+        let source: EventSource | undefined;
+        let timeout: number | undefined;
+        let retry = 0;
+
+        const reconnect = () => {
+            source?.close();
+            retry++;
+
+            if (retry >= reconnectAttempts) {
+                latest.current.dead();
+                return;
             }
 
-            return true;
+            timeout = window.setTimeout(open, 1000);
+        };
+
+        const open = () => {
+            const params = new URLSearchParams({
+                roomID: roomID,
+                key: roomKey ?? '',
+                invite: invite ?? '',
+                nickname: latest.current.nickname,
+                token: sessionStorage.getItem(reconnectTokenKey(roomID)) ?? '',
+                spectator: latest.current.spectator ? 'true' : 'false',
+                patches: 'true',
+                codiesVersion: codiesVersion,
+            });
+
+            source = new EventSource(`/api/events?${params}`);
+            source.onopen = () => latest.current.onOpen();
+            source.onmessage = (e: MessageEvent) => {
+                retry = 0;
+                setLastJsonMessage(JSON.parse(e.data));
+            };
+            source.addEventListener('close', (e) => {
+                source?.close();
+                const { code, reason } = CloseEventData.parse(JSON.parse((e as MessageEvent).data));
+                if (!closed(code, reason, latest.current.dead)) {
+                    reconnect();
+                }
+            });
+            // EventSource would reconnect by itself, but with a stale token.
+            source.onerror = reconnect;
+        };
+
+        open();
+
+        return () => {
+            source?.close();
+            window.clearTimeout(timeout);
+        };
+    }, [connect, roomID, roomKey, invite]);
+
+    const sendJsonMessage = React.useCallback(
+        (note: ClientNote) => {
+            fetch(`/api/room/${roomID}/action`, {
+                method: 'POST',
+                headers: {
+                    'Content-Type': 'application/json',
+                    'X-CODIES-VERSION': codiesVersion,
+                    Authorization: `Bearer ${sessionStorage.getItem(reconnectTokenKey(roomID)) ?? ''}`,
+                },
+                body: JSON.stringify(note),
+            }).catch(noop);
         },
-    });
+        [roomID]
+    );
+
+    return { sendJsonMessage, lastJsonMessage };
+}
+
+// createInvite creates an invite to the room, returning a link which joins it.
+async function createInvite(roomID: string): Promise<string | undefined> {
+    try {
+        const response = await fetch(`/api/room/${roomID}/invite`, {
+            method: 'POST',
+            headers: {
+                'Content-Type': 'application/json',
+                'X-CODIES-VERSION': codiesVersion,
+                Authorization: `Bearer ${sessionStorage.getItem(reconnectTokenKey(roomID)) ?? ''}`,
+            },
+            body: JSON.stringify({}),
+        });
+        if (!response.ok) {
+            return undefined;
+        }
+        const resp = InviteResponse.parse(await response.json());
+        return `${window.location.origin}/?invite=${resp.token}`;
+    } catch {
+        return undefined;
+    }
 }
 
 function useSyncedServerTime() {
@@ -140,6 +376,57 @@ function useSyncedServerTime() {
     return syncTime;
 }
 
+// The number of raw states kept to apply patches to; see patch.go.
+const maxPatchBases = 16;
+
+// usePatches keeps the raw JSON of recent states, so that patches against them
+// can be applied, and acknowledges each state once it has been applied.
+function usePatches(sendNote: (r: ClientNote) => void) {
+    const sendNoteRef = React.useRef(sendNote);
+    sendNoteRef.current = sendNote;
+    const states = React.useRef(new Map<number, unknown>());
+
+    const ack = React.useCallback(
+        (version: number) => sendNoteRef.current({ method: 'ack', version, params: { version } }),
+        [sendNoteRef]
+    );
+
+    const received = React.useCallback(
+        (version: number, raw: unknown) => {
+            const m = states.current;
+            m.set(version, raw);
+            for (const v of m.keys()) {
+                if (m.size <= maxPatchBases) {
+                    break;
+                }
+                m.delete(v);
+            }
+            ack(version);
+        },
+        [ack]
+    );
+
+    // patched returns the raw state a patch produces, or undefined after
+    // asking the server for a full state if it can't be applied.
+    const patched = React.useCallback(
+        (from: number, ops: Parameters<typeof applyPatch>[1]): unknown => {
+            const base = states.current.get(from);
+            if (base !== undefined) {
+                try {
+                    return applyPatch(base, ops);
+                } catch {
+                    // Fall through.
+                }
+            }
+            ack(-1);
+            return undefined;
+        },
+        [ack]
+    );
+
+    return { received, patched };
+}
+
 type StateAction = { method: 'setState'; state: State } | PartialClientNote;
 
 function useStateReducer(sendNote: (r: ClientNote) => void) {
@@ -147,6 +434,7 @@ function useStateReducer(sendNote: (r: ClientNote) => void) {
     // TODO: Put sendNote in the state instead of reffing it?
     const sendNoteRef = React.useRef(sendNote);
     sendNoteRef.current = sendNote;
+    const nextID = React.useRef(0);
 
     return React.useCallback(
         (state: State | undefined, action: StateAction): State | undefined => {
@@ -161,30 +449,81 @@ function useStateReducer(sendNote: (r: ClientNote) => void) {
                 case 'setState':
                     return action.state;
                 default:
-                    sendNoteRef.current({ ...action, version: state.roomState.version });
+                    nextID.current++;
+                    sendNoteRef.current({ ...action, id: nextID.current, version: state.roomState.version });
                     return state;
             }
         },
-        [sendNoteRef]
+        [sendNoteRef, nextID]
     );
 }
 
-export interface GameProps {
+// Why an action had no effect. Stale versions aren't shown, as the latest
+// state has already been sent and the player can simply try again.
+const resultMessages: Record<Exclude<ResultCode, 'stale_version'>, string> = {
+    not_your_turn: "It's not your turn.",
+    invalid_params: "That isn't allowed by the game's rules.",
+    forbidden: "You can't do that right now.",
+    rate_limited: "You're doing that too often; slow down.",
+};
+
+// How a new player may join a room: with its key, given to those who know its
+// password, or an invite. Neither is needed for public rooms, or once the
+// player has a reconnect token.
+export interface RoomAccess {
+    roomKey?: string;
+    invite?: string;
+}
+
+export interface GameProps extends RoomAccess {
     roomID: string;
     nickname: string;
+    spectator: boolean;
     leave: () => void;
 }
 
 export const Game = (props: DeepReadonly<GameProps>) => {
     const nickname = React.useRef(props.nickname); // Preserve a nickname for use in reconnects.
+    const spectator = React.useRef(props.spectator); // Likewise for whether the player is watching.
 
     const syncTime = useSyncedServerTime();
-    const { sendJsonMessage, lastJsonMessage } = useWS(props.roomID, nickname.current, props.leave, syncTime);
+    const [eventStream, setEventStream] = React.useState(() => sessionStorage.getItem(eventsTransportKey) === 'true');
+    const fallback = React.useCallback(() => {
+        sessionStorage.setItem(eventsTransportKey, 'true');
+        setEventStream(true);
+    }, []);
+
+    const ws = useWS(
+        props.roomID,
+        props.roomKey,
+        props.invite,
+        nickname.current,
+        spectator.current,
+        !eventStream,
+        props.leave,
+        syncTime,
+        fallback
+    );
+    const events = useEvents(
+        props.roomID,
+        props.roomKey,
+        props.invite,
+        nickname.current,
+        spectator.current,
+        eventStream,
+        props.leave,
+        syncTime
+    );
+    const invite = React.useCallback(() => createInvite(props.roomID), [props.roomID]);
+    const { sendJsonMessage, lastJsonMessage } = eventStream ? events : ws;
 
     const reducer = useStateReducer(sendJsonMessage);
+    const { received, patched } = usePatches(sendJsonMessage);
     const [state, dispatch] = React.useReducer(reducer, undefined);
     const player = usePlayer(state);
     const send = useSender(dispatch);
+    const [chat, setChat] = React.useState<ChatMessage[]>([]);
+    const [rejected, setRejected] = React.useState<string | undefined>();
 
     React.useEffect(() => {
         if (!lastJsonMessage) {
@@ -195,12 +534,35 @@ export const Game = (props: DeepReadonly<GameProps>) => {
 
         switch (note.method) {
             case 'state':
+                sessionStorage.setItem(reconnectTokenKey(props.roomID), note.params.token);
                 dispatch({ method: 'setState', state: note.params });
+                received(note.params.roomState.version, (lastJsonMessage as { params: unknown }).params);
+                break;
+            case 'patch': {
+                const raw = patched(note.params.from, note.params.ops);
+                if (raw === undefined) {
+                    break;
+                }
+                dispatch({ method: 'setState', state: State.parse(raw) });
+                received(note.params.to, raw);
+                break;
+            }
+            case 'chat': {
+                const messages = note.params.messages;
+                setChat((chat) => appendChat(chat, messages));
                 break;
+            }
+            case 'result': {
+                const code = note.params.code;
+                if (code !== undefined && code !== 'stale_version') {
+                    setRejected(resultMessages[code]);
+                }
+                break;
+            }
             default:
                 assertNever(note.method);
         }
-    }, [lastJsonMessage]);
+    }, [lastJsonMessage, props.roomID, received, patched]);
 
     if (!state) {
         return <Loading />;
@@ -208,15 +570,29 @@ export const Game = (props: DeepReadonly<GameProps>) => {
 
     assertIsDefined(player);
     nickname.current = player.pState.nickname;
+    spectator.current = state.spectator;
 
     return (
-        <GameView
-            roomID={props.roomID}
-            leave={props.leave}
-            send={send}
-            state={state.roomState}
-            pState={player.pState}
-            pTeam={player.pTeam}
-        />
+        <>
+            <GameView
+                roomID={props.roomID}
+                roomKey={props.roomKey}
+                token={state.token}
+                leave={props.leave}
+                send={send}
+                state={state.roomState}
+                pState={player.pState}
+                pTeam={player.pTeam}
+                spectator={state.spectator}
+                chat={chat}
+                invite={invite}
+            />
+            <Snackbar
+                open={rejected !== undefined}
+                autoHideDuration={4000}
+                onClose={() => setRejected(undefined)}
+                message={rejected}
+            />
+        </>
     );
 };
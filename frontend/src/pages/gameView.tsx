@@ -7,11 +7,14 @@ import {
     Grid,
     IconButton,
     makeStyles,
+    Menu,
+    MenuItem,
     Modal,
     Paper,
     Slider,
     TextField,
     Theme,
+    Tooltip,
     Typography,
     useTheme,
 } from '@material-ui/core';
@@ -20,18 +23,22 @@ import {
     Add,
     ArrowBack,
     Delete,
+    GetApp,
     Link,
     Person,
     Search,
     Timer,
     TimerOff,
+    Tv,
     Visibility,
     VisibilityOff,
 } from '@material-ui/icons';
 import { ok as assertTrue } from 'assert';
+import copy from 'clipboard-copy';
 import isArray from 'lodash/isArray';
 import range from 'lodash/range';
 import { DropzoneDialog } from 'material-ui-dropzone';
+import querystring from 'querystring';
 import * as React from 'react';
 import isEqual from 'react-fast-compare';
 import { Controller, useForm } from 'react-hook-form';
@@ -41,15 +48,29 @@ import { isDefined, nameofFactory, noComplete } from '../common';
 import { Board } from '../components/board';
 import { ClipboardButton } from '../components/clipboard';
 import { useServerTime } from '../hooks';
-import { RoomState, StatePlayer, StateTeams, StateTimer, StateWordList, WordPack } from '../protocol';
+import {
+    ChatMessage,
+    GameMode,
+    RoomState,
+    StateClue,
+    StateDuet,
+    StateEvent,
+    StatePlayer,
+    StateTeams,
+    StateTimer,
+    StateWordList,
+    UndoRule,
+    WordPack,
+} from '../protocol';
 import { teamSpecs } from '../teams';
 
 export interface Sender {
     reveal: (row: number, col: number) => void;
-    newGame: () => void;
+    newGame: (seed?: string) => void;
     endTurn: () => void;
     changeNickname: (nickname: string) => void;
     changeRole: (spymaster: boolean) => void;
+    spectate: () => void;
     changeTeam: (team: number) => void;
     randomizeTeams: () => void;
     changePack: (num: number, enable: boolean) => void;
@@ -58,6 +79,22 @@ export interface Sender {
     addPacks: (packs: { name: string; words: string[] }[]) => void;
     removePack: (num: number) => void;
     changeHideBomb: (HideBomb: boolean) => void;
+    changeNumTeams: (numTeams: number) => void;
+    changeBoardSize: (rows: number, cols: number) => void;
+    changeMode: (mode: GameMode) => void;
+    giveClue: (word: string, count: number, unlimited: boolean) => void;
+    undo: () => void;
+    changeUndoRule: (rule: UndoRule) => void;
+    changeRevealShare: (share: number) => void;
+    changeHost: (playerID: string) => void;
+    kick: (playerID: string) => void;
+    ban: (playerID: string, nickname: string) => void;
+    changeLocked: (locked: boolean) => void;
+    changeHostOnly: (hostOnly: boolean) => void;
+    revokeInvites: () => void;
+    changeStreamSafe: (streamSafe: boolean) => void;
+    chat: (text: string, team: boolean) => void;
+    changeHideTeamChat: (hideTeamChat: boolean) => void;
 }
 
 const useCenterStyles = makeStyles((_theme: Theme) =>
@@ -75,12 +112,25 @@ const useCenterStyles = makeStyles((_theme: Theme) =>
 
 interface CenterTextProps {
     winner: number | undefined | null;
+    duet: StateDuet | undefined | null;
     timer: StateTimer | undefined | null;
     turn: number;
     myTurn: boolean;
 }
 
-const CenterText = ({ winner, timer, turn, myTurn }: DeepReadonly<CenterTextProps>) => {
+function duetText(duet: DeepReadonly<StateDuet>, myTurn: boolean): string {
+    if (duet.won) {
+        return 'You win!';
+    }
+
+    if (duet.lost) {
+        return 'You lose!';
+    }
+
+    return `${myTurn ? 'Your turn' : 'Give a clue'} (${duet.timerTokens} turns left)`;
+}
+
+const CenterText = ({ winner, duet, timer, turn, myTurn }: DeepReadonly<CenterTextProps>) => {
     const classes = useCenterStyles();
     const [countdown, setCountdown] = React.useState<number | undefined>();
     const { now } = useServerTime();
@@ -123,7 +173,9 @@ const CenterText = ({ winner, timer, turn, myTurn }: DeepReadonly<CenterTextProp
     }, [countdown, winner, deadline, now]);
 
     const centerText = React.useMemo(() => {
-        const text = isDefined(winner)
+        const text = isDefined(duet)
+            ? duetText(duet, myTurn)
+            : isDefined(winner)
             ? `${teamSpecs[winner].name} wins!`
             : myTurn
             ? 'Your turn'
@@ -134,7 +186,7 @@ const CenterText = ({ winner, timer, turn, myTurn }: DeepReadonly<CenterTextProp
         }
 
         return `${text} [${countdown}s]`;
-    }, [winner, turn, myTurn, countdown]);
+    }, [winner, duet, turn, myTurn, countdown]);
 
     return (
         <h1
@@ -149,7 +201,9 @@ const CenterText = ({ winner, timer, turn, myTurn }: DeepReadonly<CenterTextProp
 interface HeaderProps {
     send: Sender;
     myTurn: boolean;
+    end: boolean;
     winner: number | undefined | null;
+    duet: StateDuet | undefined | null;
     spymaster: boolean;
     turn: number;
     wordsLeft: number[];
@@ -159,7 +213,9 @@ interface HeaderProps {
 const Header = React.memo(function Header({
     send,
     myTurn,
+    end,
     winner,
+    duet,
     spymaster,
     turn,
     wordsLeft,
@@ -187,14 +243,14 @@ const Header = React.memo(function Header({
                 </h1>
             </Grid>
             <Grid item xs style={{ textAlign: 'center' }}>
-                <CenterText winner={winner} timer={timer} turn={turn} myTurn={myTurn} />
+                <CenterText winner={winner} duet={duet} timer={timer} turn={turn} myTurn={myTurn} />
             </Grid>
             <Grid item xs style={{ textAlign: 'right' }}>
                 <Button
                     type="button"
                     variant="outlined"
                     onClick={() => myTurn && !spymaster && send.endTurn()}
-                    disabled={!myTurn || spymaster || isDefined(winner)}
+                    disabled={!myTurn || spymaster || end}
                 >
                     End turn
                 </Button>
@@ -204,6 +260,232 @@ const Header = React.memo(function Header({
 },
 isEqual);
 
+function clueText(clue: DeepReadonly<StateClue>): string {
+    const count = clue.unlimited ? '\u221e' : `${clue.count}`;
+    const left = isDefined(clue.guessesLeft) ? ` (${clue.guessesLeft} guesses left)` : '';
+    return `${clue.word} ${count}${left}`;
+}
+
+interface ClueFormData {
+    word: string;
+    count: string;
+}
+
+const clueCounts = [...range(0, 10).map((n) => `${n}`), '\u221e'];
+
+const ClueForm = ({ send }: { send: Sender }) => {
+    const formName = React.useMemo(() => nameofFactory<ClueFormData>(), []);
+    const { control, handleSubmit, errors, reset } = useForm<ClueFormData>({});
+    const doSubmit = handleSubmit((data) => {
+        const unlimited = data.count === '\u221e';
+        send.giveClue(data.word, unlimited ? 0 : parseInt(data.count), unlimited);
+        reset();
+    });
+
+    return (
+        <form onSubmit={doSubmit} style={{ display: 'flex', justifyContent: 'center', alignItems: 'flex-end' }}>
+            <Controller
+                control={control}
+                as={TextField}
+                name={formName('word')}
+                label="Clue"
+                defaultValue=""
+                error={!!errors.word}
+                rules={{ required: true, minLength: 1, maxLength: 32 }}
+                inputProps={noComplete}
+                size="small"
+            />
+            <Controller
+                control={control}
+                as={TextField}
+                name={formName('count')}
+                label="Count"
+                defaultValue="1"
+                select
+                SelectProps={{ native: true }}
+                size="small"
+                style={{ marginLeft: '0.5rem' }}
+            >
+                {clueCounts.map((c) => (
+                    <option key={c} value={c}>
+                        {c}
+                    </option>
+                ))}
+            </Controller>
+            <Button type="submit" variant="outlined" size="small" style={{ marginLeft: '0.5rem' }}>
+                Give clue
+            </Button>
+        </form>
+    );
+};
+
+interface ClueProps {
+    send: Sender;
+    clue: StateClue | undefined | null;
+    canGiveClue: boolean;
+}
+
+const Clue = ({ send, clue, canGiveClue }: DeepReadonly<ClueProps>) => {
+    if (isDefined(clue)) {
+        return <Typography variant="h6">Clue: {clueText(clue)}</Typography>;
+    }
+
+    if (canGiveClue) {
+        return <ClueForm send={send} />;
+    }
+
+    return null;
+};
+
+function eventText(e: DeepReadonly<StateEvent>): string {
+    const who = e.nickname ?? 'Timer';
+
+    switch (e.type) {
+        case 'newGame':
+            return `${who} started a new game`;
+        case 'reveal':
+            if (isDefined(e.voters)) {
+                return `${e.voters.join(', ')} voted to reveal ${e.tile?.word}`;
+            }
+            return `${who} revealed ${e.tile?.word}`;
+        case 'clue':
+            return isDefined(e.clue) ? `${who} gave the clue ${clueText(e.clue)}` : `${who} gave a clue`;
+        case 'endTurn':
+            return `${who} ended ${teamSpecs[e.turn].name}'s turn`;
+        case 'changeTeam':
+            return isDefined(e.team) ? `${who} joined ${teamSpecs[e.team].name}` : `${who} changed teams`;
+        case 'randomizeTeams':
+            return `${who} randomized the teams`;
+        case 'changeRole':
+            return e.spymaster ? `${who} became a spymaster` : `${who} became a guesser`;
+        case 'join':
+            return `${who} joined the room`;
+        case 'leave':
+            return `${who} left the room`;
+        case 'changeNickname':
+            return `${who} changed their nickname`;
+        case 'undo': {
+            const undone = isDefined(e.undone) ? eventText(e.undone) : 'a move';
+            return `Undid "${undone}" with the agreement of ${(e.voters ?? []).join(', ')}`;
+        }
+        default:
+            return `${who}: ${e.type}`;
+    }
+}
+
+const GameLog = React.memo(function GameLog({ log }: DeepReadonly<{ log: StateEvent[] }>) {
+    return (
+        <>
+            <h2>Log</h2>
+            <Paper style={{ padding: '0.5rem', maxHeight: '15rem', overflowY: 'auto', textAlign: 'left' }}>
+                {log
+                    .slice()
+                    .reverse()
+                    .map((e, i) => (
+                        <div key={log.length - i} style={{ color: teamSpecs[e.turn].hue[600] }}>
+                            {eventText(e)}
+                        </div>
+                    ))}
+            </Paper>
+        </>
+    );
+},
+isEqual);
+
+interface ChatFormData {
+    text: string;
+}
+
+// Sync with chat.go.
+const maxChatLength = 500;
+
+interface SidebarChatProps {
+    send: Sender;
+    chat: ChatMessage[];
+    spectator: boolean;
+    hideTeamChat: boolean;
+}
+
+const SidebarChat = ({ send, chat, spectator, hideTeamChat }: DeepReadonly<SidebarChatProps>) => {
+    const formName = React.useMemo(() => nameofFactory<ChatFormData>(), []);
+    const { control, handleSubmit, errors, reset } = useForm<ChatFormData>({});
+    const [team, setTeam] = React.useState(false);
+    const doSubmit = handleSubmit((data) => {
+        send.chat(data.text, team && !spectator);
+        reset();
+    });
+
+    return (
+        <>
+            <h2>Chat</h2>
+            <Paper style={{ padding: '0.5rem', textAlign: 'left' }}>
+                <div style={{ maxHeight: '15rem', overflowY: 'auto', display: 'flex', flexDirection: 'column-reverse' }}>
+                    <div>
+                        {chat.map((m) => (
+                            <div
+                                key={m.id}
+                                title={m.time.toLocaleTimeString()}
+                                style={{ fontStyle: isDefined(m.team) ? 'italic' : undefined }}
+                            >
+                                <span style={isDefined(m.team) ? { color: teamSpecs[m.team].hue[600] } : undefined}>
+                                    {m.nickname}
+                                    {isDefined(m.team) ? ' (team)' : null}:
+                                </span>{' '}
+                                {m.text}
+                            </div>
+                        ))}
+                    </div>
+                </div>
+                <form onSubmit={(e) => e.preventDefault()} style={{ marginTop: '0.5rem' }}>
+                    <Controller
+                        control={control}
+                        as={TextField}
+                        name={formName('text')}
+                        label="Message"
+                        defaultValue=""
+                        error={!!errors.text}
+                        rules={{ required: true, minLength: 1, maxLength: maxChatLength }}
+                        fullWidth={true}
+                        inputProps={noComplete}
+                        size="small"
+                    />
+                    <ButtonGroup size="small" style={{ width: '100%', marginTop: '0.5rem' }}>
+                        <Button
+                            type="button"
+                            variant={team && !spectator ? 'outlined' : 'contained'}
+                            style={{ width: '100%' }}
+                            onClick={() => setTeam(false)}
+                        >
+                            All
+                        </Button>
+                        <Button
+                            type="button"
+                            variant={team && !spectator ? 'contained' : 'outlined'}
+                            style={{ width: '100%' }}
+                            onClick={() => setTeam(true)}
+                            disabled={spectator}
+                        >
+                            Team
+                        </Button>
+                        <Button type="submit" onClick={doSubmit} style={{ width: '100%' }}>
+                            Send
+                        </Button>
+                    </ButtonGroup>
+                    <Button
+                        type="button"
+                        variant={hideTeamChat ? 'contained' : 'outlined'}
+                        size="small"
+                        style={{ width: '100%', marginTop: '0.5rem' }}
+                        onClick={() => send.changeHideTeamChat(!hideTeamChat)}
+                    >
+                        Hide team chat from spymasters
+                    </Button>
+                </form>
+            </Paper>
+        </>
+    );
+};
+
 const sliderMarks = range(30, 301, 30).map((v) => ({ value: v }));
 
 interface TimerSliderProps {
@@ -361,11 +643,34 @@ const ChangeNicknameButton = ({ send }: { send: Sender }) => {
     );
 };
 
+interface MemberMenuProps {
+    send: Sender;
+    member: StatePlayer;
+    anchor: Element;
+    onClose: () => void;
+}
+
+const MemberMenu = ({ send, member, anchor, onClose }: DeepReadonly<MemberMenuProps>) => {
+    const act = (fn: () => void) => () => {
+        fn();
+        onClose();
+    };
+
+    return (
+        <Menu anchorEl={anchor as Element} keepMounted open={true} onClose={onClose}>
+            <MenuItem onClick={act(() => send.changeHost(member.playerID))}>Make host</MenuItem>
+            <MenuItem onClick={act(() => send.kick(member.playerID))}>Kick</MenuItem>
+            <MenuItem onClick={act(() => send.ban(member.playerID, member.nickname))}>Ban</MenuItem>
+        </Menu>
+    );
+};
+
 interface SidebarTeamsProps {
     send: Sender;
     teams: StateTeams;
     pTeam: number;
     playerID: string;
+    host: string;
 }
 
 const SidebarTeams = React.memo(function SidebarTeams({
@@ -373,9 +678,12 @@ const SidebarTeams = React.memo(function SidebarTeams({
     teams,
     pTeam,
     playerID,
+    host,
 }: DeepReadonly<SidebarTeamsProps>) {
     const theme = useTheme();
     const nameShade = theme.palette.type === 'dark' ? 400 : 600;
+    const [menu, setMenu] = React.useState<{ member: StatePlayer; anchor: Element } | undefined>();
+    const isHost = host === playerID;
 
     return (
         <>
@@ -388,6 +696,14 @@ const SidebarTeams = React.memo(function SidebarTeams({
                         gridTemplateColumns: `repeat(${teams.length}, 1fr)`,
                     }}
                 >
+                    {!menu ? null : (
+                        <MemberMenu
+                            send={send}
+                            member={menu.member}
+                            anchor={menu.anchor}
+                            onClose={() => setMenu(undefined)}
+                        />
+                    )}
                     {teams.map((team, i) => (
                         <React.Fragment key={i}>
                             <Button
@@ -414,9 +730,18 @@ const SidebarTeams = React.memo(function SidebarTeams({
                                         gridColumn: i + 1,
                                         color: teamSpecs[i].hue[nameShade],
                                         fontStyle: member.playerID === playerID ? 'italic' : undefined,
+                                        opacity: member.away ? 0.5 : undefined,
+                                        cursor: isHost && member.playerID !== playerID ? 'pointer' : undefined,
                                     }}
+                                    onClick={
+                                        isHost && member.playerID !== playerID
+                                            ? (e) => setMenu({ member, anchor: e.currentTarget })
+                                            : undefined
+                                    }
                                 >
                                     {member.spymaster ? `[${member.nickname}]` : member.nickname}
+                                    {member.playerID === host ? ' (host)' : null}
+                                    {member.away ? ' (away)' : null}
                                 </span>
                             ))}
                         </React.Fragment>
@@ -431,6 +756,19 @@ const SidebarTeams = React.memo(function SidebarTeams({
                 >
                     Randomize teams
                 </Button>
+                <ButtonGroup size="small" style={{ width: '100%', marginTop: '0.5rem' }}>
+                    {range(2, teamSpecs.length + 1).map((n) => (
+                        <Button
+                            key={n}
+                            type="button"
+                            variant={teams.length === n ? 'contained' : 'outlined'}
+                            style={{ width: '100%' }}
+                            onClick={() => send.changeNumTeams(n)}
+                        >
+                            {n} teams
+                        </Button>
+                    ))}
+                </ButtonGroup>
                 <ChangeNicknameButton send={send} />
             </Paper>
         </>
@@ -452,9 +790,10 @@ const useSidebarPacksStyles = makeStyles((_theme: Theme) =>
 interface SidebarPacksProps {
     send: Sender;
     lists: StateWordList[];
+    tiles: number; // Tiles on the next game's board, which each pack must be able to fill.
 }
 
-const SidebarPacks = React.memo(function SidebarPacks({ send, lists }: DeepReadonly<SidebarPacksProps>) {
+const SidebarPacks = React.memo(function SidebarPacks({ send, lists, tiles }: DeepReadonly<SidebarPacksProps>) {
     const classes = useSidebarPacksStyles();
 
     const wordCount = React.useMemo(
@@ -528,7 +867,7 @@ const SidebarPacks = React.memo(function SidebarPacks({ send, lists }: DeepReado
                                         .map((word) => word.trim())
                                         .filter((word) => word);
 
-                                    if (words.length < 25) {
+                                    if (words.length < tiles) {
                                         continue;
                                     }
 
@@ -547,6 +886,272 @@ const SidebarPacks = React.memo(function SidebarPacks({ send, lists }: DeepReado
     );
 }, isEqual);
 
+const boardSizes = [
+    [4, 4],
+    [5, 5],
+    [5, 6],
+    [6, 6],
+    [7, 7],
+];
+
+const modes: { mode: GameMode; name: string }[] = [
+    { mode: 'classic', name: 'Classic' },
+    { mode: 'duet', name: 'Duet' },
+];
+
+interface SidebarBoardSizeProps {
+    send: Sender;
+    mode: GameMode;
+    rows: number;
+    cols: number;
+}
+
+const SidebarBoardSize = React.memo(function SidebarBoardSize({ send, mode, rows, cols }: SidebarBoardSizeProps) {
+    return (
+        <>
+            <h2>Board</h2>
+            <ButtonGroup size="small" style={{ width: '100%', marginBottom: '0.5rem' }}>
+                {modes.map((m) => (
+                    <Button
+                        key={m.mode}
+                        type="button"
+                        variant={mode === m.mode ? 'contained' : 'outlined'}
+                        style={{ width: '100%' }}
+                        onClick={() => send.changeMode(m.mode)}
+                    >
+                        {m.name}
+                    </Button>
+                ))}
+            </ButtonGroup>
+            <ButtonGroup size="small" style={{ width: '100%' }}>
+                {boardSizes.map(([r, c]) => (
+                    <Button
+                        key={`${r}x${c}`}
+                        type="button"
+                        variant={rows === r && cols === c ? 'contained' : 'outlined'}
+                        style={{ width: '100%' }}
+                        onClick={() => send.changeBoardSize(r, c)}
+                    >
+                        {r}x{c}
+                    </Button>
+                ))}
+            </ButtonGroup>
+        </>
+    );
+});
+
+const undoRules: { rule: UndoRule; name: string }[] = [
+    { rule: 'spymaster', name: 'Spymaster' },
+    { rule: 'majority', name: 'Majority' },
+];
+
+const revealShares: { share: number; name: string }[] = [
+    { share: 0, name: 'First click' },
+    { share: 51, name: 'Majority' },
+    { share: 100, name: 'Everyone' },
+];
+
+const SidebarRules = React.memo(function SidebarRules({
+    send,
+    undoRule,
+    revealShare,
+}: {
+    send: Sender;
+    undoRule: UndoRule;
+    revealShare: number;
+}) {
+    return (
+        <>
+            <h2>Reveal when</h2>
+            <ButtonGroup size="small" style={{ width: '100%', marginBottom: '0.5rem' }}>
+                {revealShares.map((r) => (
+                    <Button
+                        key={r.share}
+                        type="button"
+                        variant={revealShare === r.share ? 'contained' : 'outlined'}
+                        style={{ width: '100%' }}
+                        onClick={() => send.changeRevealShare(r.share)}
+                    >
+                        {r.name}
+                    </Button>
+                ))}
+            </ButtonGroup>
+            <h2>Undo consent</h2>
+            <ButtonGroup size="small" style={{ width: '100%' }}>
+                {undoRules.map((u) => (
+                    <Button
+                        key={u.rule}
+                        type="button"
+                        variant={undoRule === u.rule ? 'contained' : 'outlined'}
+                        style={{ width: '100%' }}
+                        onClick={() => send.changeUndoRule(u.rule)}
+                    >
+                        {u.name}
+                    </Button>
+                ))}
+            </ButtonGroup>
+        </>
+    );
+});
+
+interface SidebarSpectatorsProps {
+    send: Sender;
+    spectators: StatePlayer[];
+    streamSafe: boolean;
+    playerID: string;
+}
+
+const SidebarSpectators = React.memo(function SidebarSpectators({
+    send,
+    spectators,
+    streamSafe,
+    playerID,
+}: DeepReadonly<SidebarSpectatorsProps>) {
+    return (
+        <>
+            <h2>Spectators</h2>
+            <Paper style={{ padding: '0.5rem' }}>
+                {spectators.length === 0 ? (
+                    <Typography variant="body2">Nobody is watching.</Typography>
+                ) : (
+                    spectators.map((s) => (
+                        <Typography
+                            key={s.playerID}
+                            variant="body2"
+                            style={{ fontStyle: s.playerID === playerID ? 'italic' : undefined }}
+                        >
+                            {s.nickname}
+                        </Typography>
+                    ))
+                )}
+                <Button
+                    type="button"
+                    variant={streamSafe ? 'contained' : 'outlined'}
+                    size="small"
+                    style={{ width: '100%', marginTop: '0.5rem' }}
+                    onClick={() => send.changeStreamSafe(!streamSafe)}
+                >
+                    Stream safe
+                </Button>
+            </Paper>
+        </>
+    );
+});
+
+const InviteButton = ({ invite }: { invite: () => Promise<string | undefined> }) => {
+    const [message, setMessage] = React.useState<string | undefined>();
+
+    return (
+        <Tooltip
+            open={message !== undefined}
+            title={message ?? ''}
+            leaveDelay={2000}
+            onClose={() => setMessage(undefined)}
+        >
+            <Button
+                type="button"
+                variant="outlined"
+                style={{ width: '100%' }}
+                onClick={async () => {
+                    const link = await invite();
+                    if (link) {
+                        copy(link);
+                    }
+                    setMessage(link ? 'Copied to clipboard.' : 'Could not create an invite.');
+                }}
+            >
+                Copy invite link
+            </Button>
+        </Tooltip>
+    );
+};
+
+const SidebarHost = React.memo(function SidebarHost({
+    send,
+    locked,
+    hostOnly,
+    invites,
+    invite,
+}: {
+    send: Sender;
+    locked: boolean;
+    hostOnly: boolean;
+    invites: number;
+    invite: () => Promise<string | undefined>;
+}) {
+    return (
+        <>
+            <h2>Host</h2>
+            <ButtonGroup size="small" style={{ width: '100%' }}>
+                <Button
+                    type="button"
+                    variant={locked ? 'contained' : 'outlined'}
+                    style={{ width: '100%' }}
+                    onClick={() => send.changeLocked(!locked)}
+                >
+                    {locked ? 'Unlock room' : 'Lock room'}
+                </Button>
+                <Button
+                    type="button"
+                    variant={hostOnly ? 'contained' : 'outlined'}
+                    style={{ width: '100%' }}
+                    onClick={() => send.changeHostOnly(!hostOnly)}
+                >
+                    Host only settings
+                </Button>
+            </ButtonGroup>
+            <ButtonGroup size="small" style={{ width: '100%', marginTop: '0.5rem' }}>
+                <InviteButton invite={invite} />
+                <Button
+                    type="button"
+                    variant="outlined"
+                    style={{ width: '100%' }}
+                    disabled={invites === 0}
+                    onClick={send.revokeInvites}
+                >
+                    Revoke invites ({invites})
+                </Button>
+            </ButtonGroup>
+        </>
+    );
+});
+
+interface UndoProps {
+    send: Sender;
+    undo: RoomState['undo'];
+    teams: StateTeams;
+    playerID: string;
+}
+
+const Undo = ({ send, undo, teams, playerID }: DeepReadonly<UndoProps>) => {
+    if (!isDefined(undo)) {
+        return null;
+    }
+
+    const nicknames = new Map(teams.flat().map((p) => [p.playerID, p.nickname]));
+    const votes = undo.votes.map((id) => nicknames.get(id) ?? id);
+    const voted = undo.votes.includes(playerID);
+
+    return (
+        <div style={{ display: 'flex', justifyContent: 'center', alignItems: 'center', marginTop: '0.5rem' }}>
+            <Typography variant="body2">
+                Last move: {eventText(undo.event)}
+                {votes.length > 0 ? ` (undo requested by ${votes.join(', ')})` : null}
+            </Typography>
+            <Button
+                type="button"
+                variant="outlined"
+                size="small"
+                style={{ marginLeft: '0.5rem' }}
+                onClick={send.undo}
+                disabled={voted}
+            >
+                {votes.length > 0 ? 'Agree to undo' : 'Undo'}
+            </Button>
+        </div>
+    );
+};
+
 interface SidebarProps {
     send: Sender;
     teams: StateTeams;
@@ -555,13 +1160,61 @@ interface SidebarProps {
     playerID: string;
     version: number;
     timer: StateTimer | undefined | null;
+    mode: GameMode;
+    rows: number;
+    cols: number;
+    log: StateEvent[];
+    undoRule: UndoRule;
+    revealShare: number;
+    host: string;
+    locked: boolean;
+    hostOnly: boolean;
+    streamSafe: boolean;
+    spectators: StatePlayer[];
+    spectator: boolean;
+    chat: ChatMessage[];
+    hideTeamChat: boolean;
+    invites: number;
+    invite: () => Promise<string | undefined>;
 }
 
-const Sidebar = ({ send, teams, lists, pTeam, playerID, version, timer }: DeepReadonly<SidebarProps>) => {
+const Sidebar = ({
+    send,
+    teams,
+    lists,
+    pTeam,
+    playerID,
+    version,
+    timer,
+    mode,
+    rows,
+    cols,
+    log,
+    undoRule,
+    revealShare,
+    host,
+    locked,
+    hostOnly,
+    streamSafe,
+    spectators,
+    spectator,
+    chat,
+    hideTeamChat,
+    invites,
+    invite,
+}: DeepReadonly<SidebarProps>) => {
     return (
         <>
-            <SidebarTeams send={send} teams={teams} pTeam={pTeam} playerID={playerID} />
-            <SidebarPacks send={send} lists={lists} />
+            <SidebarTeams send={send} teams={teams} pTeam={pTeam} playerID={playerID} host={host} />
+            <SidebarSpectators send={send} spectators={spectators} streamSafe={streamSafe} playerID={playerID} />
+            {host === playerID ? (
+                <SidebarHost send={send} locked={locked} hostOnly={hostOnly} invites={invites} invite={invite} />
+            ) : null}
+            <SidebarPacks send={send} lists={lists} tiles={rows * cols} />
+            <SidebarBoardSize send={send} mode={mode} rows={rows} cols={cols} />
+            <SidebarRules send={send} undoRule={undoRule} revealShare={revealShare} />
+            <SidebarChat send={send} chat={chat} spectator={spectator} hideTeamChat={hideTeamChat} />
+            <GameLog log={log} />
             {!isDefined(timer) ? null : (
                 <div style={{ textAlign: 'left', marginTop: '1rem' }}>
                     <TimerSlider version={version} timer={timer} onCommit={send.changeTurnTime} />
@@ -591,15 +1244,58 @@ const useFooterStyles = makeStyles((_theme: Theme) =>
     })
 );
 
+interface SeedFormData {
+    seed: string;
+}
+
+const SeedForm = ({ send }: { send: Sender }) => {
+    const formName = React.useMemo(() => nameofFactory<SeedFormData>(), []);
+    const { control, handleSubmit, errors, reset } = useForm<SeedFormData>({});
+    const doSubmit = handleSubmit((data) => {
+        send.newGame(data.seed);
+        reset();
+    });
+
+    return (
+        <form onSubmit={doSubmit} style={{ display: 'flex', alignItems: 'flex-end', marginRight: '0.5rem' }}>
+            <Controller
+                control={control}
+                as={TextField}
+                name={formName('seed')}
+                label="Seed"
+                defaultValue=""
+                error={!!errors.seed}
+                rules={{ required: true, pattern: /^[0-9]{1,19}$/ }}
+                inputProps={noComplete}
+                size="small"
+                style={{ width: '8rem' }}
+            />
+            <Button type="submit" variant="outlined" size="small" style={{ marginLeft: '0.5rem' }}>
+                Deal seed
+            </Button>
+        </form>
+    );
+};
+
 interface FooterProps {
     send: Sender;
     end: boolean;
     spymaster: boolean;
+    spectator: boolean;
     hideBomb: boolean;
     hasTimer: boolean;
+    seed: string | undefined | null;
 }
 
-const Footer = React.memo(function Footer({ send, end, spymaster, hideBomb, hasTimer }: DeepReadonly<FooterProps>) {
+const Footer = React.memo(function Footer({
+    send,
+    end,
+    spymaster,
+    spectator,
+    hideBomb,
+    hasTimer,
+    seed,
+}: DeepReadonly<FooterProps>) {
     const classes = useFooterStyles();
 
     return (
@@ -608,10 +1304,10 @@ const Footer = React.memo(function Footer({ send, end, spymaster, hideBomb, hasT
                 <ButtonGroup variant="outlined" className={classes.leftButton}>
                     <Button
                         type="button"
-                        variant={spymaster ? undefined : 'contained'}
+                        variant={spymaster || spectator ? undefined : 'contained'}
                         onClick={() => send.changeRole(false)}
                         startIcon={<Search />}
-                        disabled={end}
+                        disabled={end && !spectator}
                     >
                         Guesser
                     </Button>
@@ -624,6 +1320,14 @@ const Footer = React.memo(function Footer({ send, end, spymaster, hideBomb, hasT
                     >
                         Spymaster
                     </Button>
+                    <Button
+                        type="button"
+                        variant={spectator ? 'contained' : undefined}
+                        onClick={send.spectate}
+                        startIcon={<Tv />}
+                    >
+                        Spectator
+                    </Button>
                 </ButtonGroup>
                 <ButtonGroup variant="outlined" className={classes.leftButton}>
                     <Button
@@ -660,13 +1364,19 @@ const Footer = React.memo(function Footer({ send, end, spymaster, hideBomb, hasT
                     </Button>
                 </ButtonGroup>
             </div>
-            <div>
+            <div style={{ display: 'flex', alignItems: 'flex-end' }}>
+                {isDefined(seed) ? (
+                    <Typography variant="body2" style={{ marginRight: '0.5rem' }}>
+                        Seed: {seed}
+                    </Typography>
+                ) : null}
+                <SeedForm send={send} />
                 <Button
                     type="button"
                     variant={end ? 'contained' : 'outlined'}
                     color={end ? undefined : 'secondary'}
                     style={end ? { color: 'white', backgroundColor: green[500] } : undefined}
-                    onClick={send.newGame}
+                    onClick={() => send.newGame()}
                 >
                     New game
                 </Button>
@@ -689,7 +1399,17 @@ const useCornerButtonsStyle = makeStyles((_theme: Theme) =>
     })
 );
 
-const CornerButtons = React.memo(function CornerButtons({ roomID, leave }: { roomID: string; leave: () => void }) {
+const CornerButtons = React.memo(function CornerButtons({
+    roomID,
+    roomKey,
+    token,
+    leave,
+}: {
+    roomID: string;
+    roomKey?: string;
+    token: string;
+    leave: () => void;
+}) {
     const classes = useCornerButtonsStyle();
 
     return (
@@ -698,11 +1418,22 @@ const CornerButtons = React.memo(function CornerButtons({ roomID, leave }: { roo
                 <Button type="button" onClick={leave} startIcon={<ArrowBack />} className={classes.button}>
                     Leave
                 </Button>
-                <ClipboardButton
-                    buttonText="Copy Room URL"
-                    toCopy={`${window.location.origin}/?roomID=${roomID}`}
-                    icon={<Link />}
-                />
+                {/* Players who joined with an invite don't have the key to give out. */}
+                {roomKey ? (
+                    <ClipboardButton
+                        buttonText="Copy Room URL"
+                        toCopy={`${window.location.origin}/?${querystring.stringify({ roomID, key: roomKey })}`}
+                        icon={<Link />}
+                    />
+                ) : null}
+                <Button
+                    href={`/api/room/${roomID}/replay?${querystring.stringify({ token })}`}
+                    download={`codies-${roomID}.json`}
+                    startIcon={<GetApp />}
+                    className={classes.button}
+                >
+                    Last Replay
+                </Button>
             </div>
         </>
     );
@@ -752,32 +1483,57 @@ const useStyles = makeStyles((theme: Theme) =>
 
 export interface GameViewProps {
     roomID: string;
+    roomKey?: string; // Lets new players join; only known to those who joined without an invite.
+    token: string; // The player's reconnect token, which is needed to download replays.
     leave: () => void;
     send: Sender;
     state: RoomState;
     pState: StatePlayer;
-    pTeam: number;
+    pTeam: number; // -1 for spectators.
+    spectator: boolean;
+    chat: ChatMessage[];
+    invite: () => Promise<string | undefined>; // Creates an invite link to the room.
 }
 
-export const GameView = ({ roomID, leave, send, state, pState, pTeam }: DeepReadonly<GameViewProps>) => {
+export const GameView = ({
+    roomID,
+    roomKey,
+    token,
+    leave,
+    send,
+    state,
+    pState,
+    pTeam,
+    spectator,
+    chat,
+    invite,
+}: DeepReadonly<GameViewProps>) => {
     const classes = useStyles();
-    const end = isDefined(state.winner);
+    const end = isDefined(state.winner) || !!state.duet?.won || !!state.duet?.lost;
     const myTurn = state.turn === pTeam;
+    // In duet games, the side which isn't guessing gives the clue.
+    const canGiveClue = !end && !spectator && (isDefined(state.duet) ? !myTurn : myTurn && pState.spymaster);
 
     return (
         <div className={classes.root}>
-            <CornerButtons roomID={roomID} leave={leave} />
+            <CornerButtons roomID={roomID} roomKey={roomKey} token={token} leave={leave} />
             <div className={classes.wrapper}>
                 <div className={classes.header}>
                     <Header
                         send={send}
                         myTurn={myTurn}
+                        end={end}
                         winner={state.winner}
+                        duet={state.duet}
                         spymaster={pState.spymaster}
                         turn={state.turn}
                         wordsLeft={state.wordsLeft}
                         timer={state.timer}
                     />
+                    <Clue send={send} clue={state.clue} canGiveClue={canGiveClue} />
+                    {spectator ? null : (
+                        <Undo send={send} undo={state.undo} teams={state.teams} playerID={pState.playerID} />
+                    )}
                 </div>
                 <div className={classes.board}>
                     <Board
@@ -793,8 +1549,10 @@ export const GameView = ({ roomID, leave, send, state, pState, pTeam }: DeepRead
                         send={send}
                         end={end}
                         spymaster={pState.spymaster}
+                        spectator={spectator}
                         hideBomb={state.hideBomb}
                         hasTimer={isDefined(state.timer)}
+                        seed={state.seed}
                     />
                 </div>
                 <div className={classes.sidebar}>
@@ -806,6 +1564,22 @@ export const GameView = ({ roomID, leave, send, state, pState, pTeam }: DeepRead
                         playerID={pState.playerID}
                         version={state.version}
                         timer={state.timer}
+                        mode={state.mode}
+                        rows={state.rows}
+                        cols={state.cols}
+                        log={state.log}
+                        undoRule={state.undoRule}
+                        revealShare={state.revealShare}
+                        host={state.host}
+                        locked={state.locked}
+                        hostOnly={state.hostOnly}
+                        streamSafe={state.streamSafe}
+                        spectators={state.spectators}
+                        spectator={spectator}
+                        chat={chat}
+                        hideTeamChat={state.hideTeamChat}
+                        invites={state.invites}
+                        invite={invite}
                     />
                 </div>
             </div>
@@ -4,9 +4,11 @@ import querystring from 'querystring';
 import * as React from 'react';
 
 import { assertIsDefined, isDefined, reloadOutdatedPage } from '../common';
+import { Lobby } from '../components/lobby';
 import { LoginForm, LoginFormData } from '../components/loginForm';
 import { version } from '../metadata.json';
 import { RoomResponse } from '../protocol';
+import { RoomAccess } from './game';
 
 function checkOutdated(response: Response) {
     if (response.status === 418) {
@@ -17,7 +19,7 @@ function checkOutdated(response: Response) {
 }
 
 export interface LoginProps {
-    onLogin: (roomID: string, nickname: string) => void;
+    onLogin: (roomID: string, nickname: string, spectator: boolean, access: RoomAccess) => void;
 }
 
 const useStyles = makeStyles((theme: Theme) =>
@@ -42,23 +44,29 @@ export const Login = (props: LoginProps) => {
     const classes = useStyles();
 
     const [roomID, setRoomID] = React.useState<string | undefined>();
+    const [roomKey, setRoomKey] = React.useState<string | undefined>();
+    const [invite, setInvite] = React.useState<string | undefined>();
 
     React.useLayoutEffect(() => {
         const location = window.location;
         if (location && location.search) {
             const query = querystring.parse(location.search.substring(1));
-            let parsed = query.roomID;
-            if (parsed === undefined) {
-                return;
-            }
+            const first = (parsed: string | string[] | undefined) => (isArray(parsed) ? parsed[0] : parsed);
 
-            if (isArray(parsed)) {
-                parsed = parsed[0];
+            const parsedRoomID = first(query.roomID);
+            const parsedRoomKey = first(query.key);
+            const parsedInvite = first(query.invite);
+            if (parsedRoomID === undefined && parsedInvite === undefined) {
+                return;
             }
 
-            setRoomID(parsed);
+            setRoomID(parsedRoomID);
+            setRoomKey(parsedRoomKey);
+            setInvite(parsedInvite);
 
             delete query.roomID;
+            delete query.key;
+            delete query.invite;
 
             const newQuery = querystring.stringify(query);
             const path = location.pathname + (newQuery ? '?' + newQuery : '');
@@ -74,9 +82,10 @@ export const Login = (props: LoginProps) => {
                     Codies
                 </Typography>
                 <LoginForm
-                    existingRoom={!!roomID}
+                    existingRoom={!!roomID || !!invite}
                     onSubmit={async (d: LoginFormData) => {
                         let id = roomID;
+                        let key = roomKey;
 
                         const headers = {
                             'X-CODIES-VERSION': version,
@@ -97,6 +106,7 @@ export const Login = (props: LoginProps) => {
                             if (!response.ok) {
                                 setErrorMessage('Room does not exist.');
                                 setRoomID(undefined);
+                                setRoomKey(undefined);
                                 return;
                             }
                         } else {
@@ -104,11 +114,16 @@ export const Login = (props: LoginProps) => {
                             let resp: RoomResponse | undefined;
 
                             try {
-                                const reqBody = JSON.stringify({
-                                    roomName: d.roomName,
-                                    roomPass: d.roomPass,
-                                    create: d.create,
-                                });
+                                const reqBody = JSON.stringify(
+                                    invite
+                                        ? { invite, create: false }
+                                        : {
+                                              roomName: d.roomName,
+                                              roomPass: d.roomPass,
+                                              create: d.create,
+                                              public: d.public,
+                                          }
+                                );
                                 response = await fetch('/api/room', { method: 'POST', body: reqBody, headers });
 
                                 if (checkOutdated(response)) {
@@ -124,17 +139,20 @@ export const Login = (props: LoginProps) => {
 
                             if (!isDefined(resp) || !response.ok || !resp.id) {
                                 setErrorMessage(resp?.error || 'An unknown error occurred.');
+                                setInvite(undefined);
                                 return;
                             }
 
                             id = resp.id;
+                            key = resp.key ?? undefined;
                         }
 
                         setErrorMessage(undefined);
-                        props.onLogin(id, d.nickname);
+                        props.onLogin(id, d.nickname, d.spectator, { roomKey: key, invite });
                     }}
                     errorMessage={errorMessage}
                 />
+                {roomID || invite ? null : <Lobby onJoin={setRoomID} />}
             </Paper>
         </div>
     );
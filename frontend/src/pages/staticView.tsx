@@ -329,4 +329,6 @@ const props = {
 
 // Static game page for testing.
 export const StaticView = () =>
-    process.env.NODE_ENV === 'development' ? GameView({ ...props, send, roomID: 'fakeRoomID', leave: noop }) : null;
+    process.env.NODE_ENV === 'development'
+        ? GameView({ ...props, send, roomID: 'fakeRoomID', token: '', leave: noop })
+        : null;
@@ -0,0 +1,394 @@
+// Code generated by schemagen. DO NOT EDIT.
+// Source: internal/protocol; see protocol.go.
+
+export interface RoomRequest {
+    roomName: string;
+    roomPass: string;
+    create: boolean;
+    public?: boolean;
+    invite?: string;
+}
+
+export interface RoomResponse {
+    id?: string;
+    key?: string;
+    error?: string;
+}
+
+export interface TimeResponse {
+    time: string;
+}
+
+export interface StatsResponse {
+    rooms: number;
+    clients: number;
+}
+
+export interface RoomsResponse {
+    rooms: LobbyRoom[];
+    total: number;
+}
+
+export interface LobbyRoom {
+    id: string;
+    name: string;
+    players: number;
+    teams: number[];
+    spectators: number;
+    mode: Mode;
+    inProgress: boolean;
+    packs: string[];
+}
+
+export interface InviteRequest {
+    expires?: number;
+    singleUse?: boolean;
+    team?: Team;
+}
+
+export interface InviteResponse {
+    token: string;
+    expires: string;
+}
+
+export interface NewGameParams {
+    seed?: string;
+}
+
+export interface EndTurnParams {}
+
+export interface RandomizeTeamsParams {}
+
+export interface RevealParams {
+    row: number;
+    col: number;
+}
+
+export interface ChangeTeamParams {
+    team: Team;
+}
+
+export interface ChangeNicknameParams {
+    nickname: string;
+}
+
+export interface ChangeRoleParams {
+    spymaster: boolean;
+    spectator?: boolean;
+}
+
+export interface ChangePackParams {
+    num: number;
+    enable: boolean;
+}
+
+export interface ChangeTurnModeParams {
+    timed: boolean;
+}
+
+export interface ChangeTurnTimeParams {
+    seconds: number;
+}
+
+export interface AddPacksParams {
+    packs: { name: string; words: string[] }[];
+}
+
+export interface RemovePackParams {
+    num: number;
+}
+
+export interface ChangeHideBombParams {
+    hideBomb: boolean;
+}
+
+export interface ChangeNumTeamsParams {
+    numTeams: number;
+}
+
+export interface ChangeBoardSizeParams {
+    rows: number;
+    cols: number;
+}
+
+export interface ChangeModeParams {
+    mode: Mode;
+}
+
+export interface GiveClueParams {
+    word: string;
+    count: number;
+    unlimited: boolean;
+}
+
+export interface UndoParams {}
+
+export interface ChangeUndoRuleParams {
+    rule: UndoRule;
+}
+
+export interface ChangeRevealShareParams {
+    share: number;
+}
+
+export interface ChatParams {
+    text: string;
+    team: boolean;
+}
+
+export interface ChangeHideTeamChatParams {
+    hideTeamChat: boolean;
+}
+
+export interface AckParams {
+    version: number;
+}
+
+export interface ChangeStreamSafeParams {
+    streamSafe: boolean;
+}
+
+export interface ChangeHostParams {
+    playerID: string;
+}
+
+export interface KickParams {
+    playerID: string;
+}
+
+export interface BanParams {
+    playerID?: string;
+    nickname?: string;
+}
+
+export interface ChangeLockedParams {
+    locked: boolean;
+}
+
+export interface ChangeHostOnlyParams {
+    hostOnly: boolean;
+}
+
+export interface RevokeInvitesParams {}
+
+export interface CloseEvent {
+    code: number;
+    reason: string;
+}
+
+export interface Result {
+    id: number;
+    ok: boolean;
+    code?: ResultCode;
+    message?: string;
+}
+
+export interface Patch {
+    from: number;
+    to: number;
+    ops: PatchOp[];
+}
+
+export interface PatchOp {
+    op: string;
+    path: string;
+    value: unknown;
+}
+
+export interface Chat {
+    messages: ChatMessage[];
+}
+
+export interface ChatMessage {
+    id: number;
+    playerID: string;
+    nickname: string;
+    text: string;
+    team: Team | null;
+    time: string;
+}
+
+export interface State {
+    playerID: string;
+    token: string;
+    spectator: boolean;
+    roomState: RoomState | null;
+}
+
+export interface RoomState {
+    mode: Mode;
+    version: number;
+    teams: StatePlayer[][];
+    numTeams: number;
+    turn: Team;
+    winner: Team | null;
+    eliminated: boolean[];
+    duet: StateDuet | null;
+    clue: StateClue | null;
+    board: StateTile[][];
+    wordsLeft: number[];
+    lists: StateWordList[];
+    log: StateEvent[];
+    seed: string | null;
+    undo: StateUndo | null;
+    undoRule: UndoRule;
+    revealShare: number;
+    timer: StateTimer | null;
+    hideBomb: boolean;
+    rows: number;
+    cols: number;
+    host: string;
+    locked: boolean;
+    hostOnly: boolean;
+    streamSafe: boolean;
+    spectators: StatePlayer[];
+    hideTeamChat: boolean;
+    invites: number;
+}
+
+export interface StatePlayer {
+    playerID: string;
+    nickname: string;
+    spymaster: boolean;
+    away: boolean;
+}
+
+export interface StateTile {
+    word: string;
+    revealed: boolean;
+    view: StateView | null;
+    marked?: boolean[];
+    votes?: string[];
+}
+
+export interface StateView {
+    team: Team;
+    neutral: boolean;
+    bomb: boolean;
+}
+
+export interface StateWordList {
+    name: string;
+    count: number;
+    custom: boolean;
+    enabled: boolean;
+}
+
+export interface StateDuet {
+    timerTokens: number;
+    won: boolean;
+    lost: boolean;
+}
+
+export interface StateClue {
+    team: Team;
+    word: string;
+    count: number;
+    unlimited: boolean;
+    guessesLeft: number | null;
+}
+
+export interface StateEvent {
+    type: EventType;
+    time: string;
+    turn: Team;
+    playerID?: string;
+    nickname?: string;
+    tile?: StateEventTile;
+    clue?: StateClue;
+    team?: Team;
+    teams?: string[][];
+    spymaster?: boolean;
+    undone?: StateEvent;
+    voters?: string[];
+}
+
+export interface StateUndo {
+    event: StateEvent | null;
+    votes: string[];
+}
+
+export interface StateEventTile {
+    row: number;
+    col: number;
+    word: string;
+    view: StateView | null;
+}
+
+export interface StateTimer {
+    turnTime: number;
+    turnEnd: string;
+}
+
+export type Mode = 'classic' | 'duet';
+
+export type Team = number;
+
+export type UndoRule = 'spymaster' | 'majority';
+
+export type ResultCode = 'not_your_turn' | 'stale_version' | 'invalid_params' | 'forbidden' | 'rate_limited';
+
+export type EventType =
+    | 'newGame'
+    | 'reveal'
+    | 'clue'
+    | 'endTurn'
+    | 'changeTeam'
+    | 'randomizeTeams'
+    | 'changeRole'
+    | 'join'
+    | 'leave'
+    | 'changeNickname'
+    | 'undo';
+
+export interface ClientParams {
+    newGame: NewGameParams;
+    endTurn: EndTurnParams;
+    randomizeTeams: RandomizeTeamsParams;
+    reveal: RevealParams;
+    changeTeam: ChangeTeamParams;
+    changeNickname: ChangeNicknameParams;
+    changeRole: ChangeRoleParams;
+    changePack: ChangePackParams;
+    changeTurnMode: ChangeTurnModeParams;
+    changeTurnTime: ChangeTurnTimeParams;
+    addPacks: AddPacksParams;
+    removePack: RemovePackParams;
+    changeHideBomb: ChangeHideBombParams;
+    changeNumTeams: ChangeNumTeamsParams;
+    changeBoardSize: ChangeBoardSizeParams;
+    changeMode: ChangeModeParams;
+    giveClue: GiveClueParams;
+    undo: UndoParams;
+    changeUndoRule: ChangeUndoRuleParams;
+    changeRevealShare: ChangeRevealShareParams;
+    chat: ChatParams;
+    changeHideTeamChat: ChangeHideTeamChatParams;
+    ack: AckParams;
+    changeStreamSafe: ChangeStreamSafeParams;
+    changeHost: ChangeHostParams;
+    kick: KickParams;
+    ban: BanParams;
+    changeLocked: ChangeLockedParams;
+    changeHostOnly: ChangeHostOnlyParams;
+    revokeInvites: RevokeInvitesParams;
+}
+
+export type ClientMethod = keyof ClientParams;
+
+export type ClientNote = {
+    [M in ClientMethod]: { id?: number; version: number; method: M; params: ClientParams[M] };
+}[ClientMethod];
+
+export interface ServerParams {
+    state: State;
+    chat: Chat;
+    patch: Patch;
+    result: Result;
+}
+
+export type ServerMethod = keyof ServerParams;
+
+export type ServerNote = {
+    [M in ServerMethod]: { method: M; params: ServerParams[M] };
+}[ServerMethod];
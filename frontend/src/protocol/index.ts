@@ -1,7 +1,10 @@
 import myzod, { Infer } from 'myzod';
 import { DeepReadonly } from 'ts-essentials';
 
-// See protocol.go.
+import * as generated from './generated';
+
+// See protocol.go, and generated.ts for the types generated from it. The
+// checks at the end of this file fail to compile if the two disagree.
 
 // Messages sent from client to server.
 
@@ -11,12 +14,18 @@ const WordPack = myzod.object({
     words: myzod.array(myzod.string()),
 });
 
+export type GameMode = Infer<typeof GameMode>;
+const GameMode = myzod.literals('classic', 'duet');
+
+export type UndoRule = Infer<typeof UndoRule>;
+const UndoRule = myzod.literals('spymaster', 'majority');
+
 export type PartialClientNote = Infer<typeof PartialClientNote>;
 export type PartialClientNoteSender = (r: PartialClientNote) => void;
 const PartialClientNote = myzod.union([
     myzod.object({
         method: myzod.literal('newGame'),
-        params: myzod.object({}),
+        params: myzod.object({ seed: myzod.string().optional() }),
     }),
     myzod.object({
         method: myzod.literal('endTurn'),
@@ -40,7 +49,7 @@ const PartialClientNote = myzod.union([
     }),
     myzod.object({
         method: myzod.literal('changeRole'),
-        params: myzod.object({ spymaster: myzod.boolean() }),
+        params: myzod.object({ spymaster: myzod.boolean(), spectator: myzod.boolean().optional() }),
     }),
     myzod.object({
         method: myzod.literal('changePack'),
@@ -68,11 +77,80 @@ const PartialClientNote = myzod.union([
         method: myzod.literal('changeHideBomb'),
         params: myzod.object({ hideBomb: myzod.boolean() }),
     }),
+    myzod.object({
+        method: myzod.literal('changeNumTeams'),
+        params: myzod.object({ numTeams: myzod.number() }),
+    }),
+    myzod.object({
+        method: myzod.literal('changeBoardSize'),
+        params: myzod.object({ rows: myzod.number(), cols: myzod.number() }),
+    }),
+    myzod.object({
+        method: myzod.literal('changeMode'),
+        params: myzod.object({ mode: GameMode }),
+    }),
+    myzod.object({
+        method: myzod.literal('giveClue'),
+        params: myzod.object({ word: myzod.string(), count: myzod.number(), unlimited: myzod.boolean() }),
+    }),
+    myzod.object({
+        method: myzod.literal('undo'),
+        params: myzod.object({}),
+    }),
+    myzod.object({
+        method: myzod.literal('changeUndoRule'),
+        params: myzod.object({ rule: UndoRule }),
+    }),
+    myzod.object({
+        method: myzod.literal('changeRevealShare'),
+        params: myzod.object({ share: myzod.number() }),
+    }),
+    myzod.object({
+        method: myzod.literal('changeHost'),
+        params: myzod.object({ playerID: myzod.string() }),
+    }),
+    myzod.object({
+        method: myzod.literal('kick'),
+        params: myzod.object({ playerID: myzod.string() }),
+    }),
+    myzod.object({
+        method: myzod.literal('ban'),
+        params: myzod.object({ playerID: myzod.string().optional(), nickname: myzod.string().optional() }),
+    }),
+    myzod.object({
+        method: myzod.literal('changeLocked'),
+        params: myzod.object({ locked: myzod.boolean() }),
+    }),
+    myzod.object({
+        method: myzod.literal('changeHostOnly'),
+        params: myzod.object({ hostOnly: myzod.boolean() }),
+    }),
+    myzod.object({
+        method: myzod.literal('revokeInvites'),
+        params: myzod.object({}),
+    }),
+    myzod.object({
+        method: myzod.literal('chat'),
+        params: myzod.object({ text: myzod.string(), team: myzod.boolean() }),
+    }),
+    myzod.object({
+        method: myzod.literal('changeHideTeamChat'),
+        params: myzod.object({ hideTeamChat: myzod.boolean() }),
+    }),
+    myzod.object({
+        method: myzod.literal('changeStreamSafe'),
+        params: myzod.object({ streamSafe: myzod.boolean() }),
+    }),
+    myzod.object({
+        method: myzod.literal('ack'),
+        params: myzod.object({ version: myzod.number() }),
+    }),
 ]);
 
 export type ClientNote = Infer<typeof ClientNote>;
 export const ClientNote = myzod
     .object({
+        id: myzod.number().optional(),
         version: myzod.number(),
     })
     .and(PartialClientNote);
@@ -82,26 +160,53 @@ export const ClientNote = myzod
 export type RoomResponse = DeepReadonly<Infer<typeof RoomResponse>>;
 export const RoomResponse = myzod.object({
     id: myzod.string().optional().nullable(),
+    key: myzod.string().optional().nullable(),
     error: myzod.string().optional().nullable(),
 });
 
+export type InviteResponse = DeepReadonly<Infer<typeof InviteResponse>>;
+export const InviteResponse = myzod.object({
+    token: myzod.string(),
+    expires: myzod.string(),
+});
+
+export type LobbyRoom = DeepReadonly<Infer<typeof LobbyRoom>>;
+const LobbyRoom = myzod.object({
+    id: myzod.string(),
+    name: myzod.string(),
+    players: myzod.number(),
+    teams: myzod.array(myzod.number()),
+    spectators: myzod.number(),
+    mode: GameMode,
+    inProgress: myzod.boolean(),
+    packs: myzod.array(myzod.string()),
+});
+
+export type RoomsResponse = DeepReadonly<Infer<typeof RoomsResponse>>;
+export const RoomsResponse = myzod.object({
+    rooms: myzod.array(LobbyRoom),
+    total: myzod.number(),
+});
+
 export type TimeResponse = DeepReadonly<Infer<typeof TimeResponse>>;
 export const TimeResponse = myzod.object({
     time: myzod.date(),
 });
 
+export type StateView = DeepReadonly<Infer<typeof StateView>>;
+const StateView = myzod.object({
+    team: myzod.number(),
+    neutral: myzod.boolean(),
+    bomb: myzod.boolean(),
+});
+
 export type StateTile = DeepReadonly<Infer<typeof StateTile>>;
 const StateTile = myzod.object({
     word: myzod.string(),
     revealed: myzod.boolean(),
-    view: myzod
-        .object({
-            team: myzod.number(),
-            neutral: myzod.boolean(),
-            bomb: myzod.boolean(),
-        })
-        .optional()
-        .nullable(),
+    view: StateView.optional().nullable(),
+    marked: myzod.array(myzod.boolean()).optional(),
+    votes: myzod.array(myzod.string()).optional(),
 });
 
 export type StateBoard = DeepReadonly<Infer<typeof StateBoard>>;
@@ -112,6 +217,7 @@ const StatePlayer = myzod.object({
     playerID: myzod.string(),
     nickname: myzod.string(),
     spymaster: myzod.boolean(),
+    away: myzod.boolean(),
 });
 
 export type StateTeams = DeepReadonly<Infer<typeof StateTeams>>;
@@ -131,29 +237,182 @@ const StateWordList = myzod.object({
     enabled: myzod.boolean(),
 });
 
+export type StateDuet = DeepReadonly<Infer<typeof StateDuet>>;
+const StateDuet = myzod.object({
+    timerTokens: myzod.number(),
+    won: myzod.boolean(),
+    lost: myzod.boolean(),
+});
+
+export type StateClue = DeepReadonly<Infer<typeof StateClue>>;
+const StateClue = myzod.object({
+    team: myzod.number(),
+    word: myzod.string(),
+    count: myzod.number(),
+    unlimited: myzod.boolean(),
+    guessesLeft: myzod.number().optional().nullable(),
+});
+
+const stateEventFields = {
+    type: myzod.string(),
+    time: myzod.date(),
+    turn: myzod.number(),
+    playerID: myzod.string().optional(),
+    nickname: myzod.string().optional(),
+    tile: myzod
+        .object({
+            row: myzod.number(),
+            col: myzod.number(),
+            word: myzod.string(),
+            view: StateView.optional().nullable(),
+        })
+        .optional(),
+    clue: StateClue.optional(),
+    team: myzod.number().optional(),
+    teams: myzod.array(myzod.array(myzod.string())).optional(),
+    spymaster: myzod.boolean().optional(),
+};
+
+// Only reveals and turn ends can be undone, so undone events never nest further.
+export type StateEvent = DeepReadonly<Infer<typeof StateEvent>>;
+const StateEvent = myzod.object({
+    ...stateEventFields,
+    undone: myzod.object(stateEventFields).optional(),
+    voters: myzod.array(myzod.string()).optional(),
+});
+
 export type RoomState = DeepReadonly<Infer<typeof RoomState>>;
 export const RoomState = myzod.object({
+    mode: GameMode,
     version: myzod.number(),
     teams: StateTeams,
+    numTeams: myzod.number(),
     turn: myzod.number(),
     winner: myzod.number().optional().nullable(),
+    eliminated: myzod.array(myzod.boolean()).optional().nullable(),
+    duet: StateDuet.optional().nullable(),
+    clue: StateClue.optional().nullable(),
     board: StateBoard,
     wordsLeft: myzod.array(myzod.number()),
     lists: myzod.array(StateWordList),
+    seed: myzod.string().nullable(),
+    undo: myzod
+        .object({
+            event: StateEvent,
+            votes: myzod.array(myzod.string()),
+        })
+        .nullable(),
+    undoRule: UndoRule,
+    revealShare: myzod.number(),
+    log: myzod.array(StateEvent),
     timer: StateTimer.optional().nullable(),
     hideBomb: myzod.boolean(),
+    rows: myzod.number(),
+    cols: myzod.number(),
+    host: myzod.string(),
+    locked: myzod.boolean(),
+    hostOnly: myzod.boolean(),
+    streamSafe: myzod.boolean(),
+    spectators: myzod.array(StatePlayer),
+    hideTeamChat: myzod.boolean(),
+    invites: myzod.number(),
 });
 
 export type State = DeepReadonly<Infer<typeof State>>;
 export const State = myzod.object({
     playerID: myzod.string(),
+    token: myzod.string(),
+    spectator: myzod.boolean(),
     roomState: RoomState,
 });
 
+export type ChatMessage = DeepReadonly<Infer<typeof ChatMessage>>;
+const ChatMessage = myzod.object({
+    id: myzod.number(),
+    playerID: myzod.string(),
+    nickname: myzod.string(),
+    text: myzod.string(),
+    team: myzod.number().nullable(),
+    time: myzod.date(),
+});
+
+export type PatchOp = DeepReadonly<Infer<typeof PatchOp>>;
+const PatchOp = myzod.object({
+    op: myzod.literals('add', 'remove', 'replace'),
+    path: myzod.string(),
+    value: myzod.unknown(),
+});
+
+// Sent as a close event to clients using server-sent events.
+export type CloseEventData = Infer<typeof CloseEventData>;
+export const CloseEventData = myzod.object({
+    code: myzod.number(),
+    reason: myzod.string(),
+});
+
+export type ResultCode = Infer<typeof ResultCode>;
+const ResultCode = myzod.literals('not_your_turn', 'stale_version', 'invalid_params', 'forbidden', 'rate_limited');
+
 export type ServerNote = DeepReadonly<Infer<typeof ServerNote>>;
 export const ServerNote = myzod.union([
     myzod.object({
         method: myzod.literal('state'),
         params: State,
     }),
+    myzod.object({
+        method: myzod.literal('chat'),
+        params: myzod.object({ messages: myzod.array(ChatMessage) }),
+    }),
+    myzod.object({
+        method: myzod.literal('patch'),
+        params: myzod.object({ from: myzod.number(), to: myzod.number(), ops: myzod.array(PatchOp) }),
+    }),
+    myzod.object({
+        method: myzod.literal('result'),
+        params: myzod.object({
+            id: myzod.number(),
+            ok: myzod.boolean(),
+            code: ResultCode.optional(),
+            message: myzod.string().optional(),
+        }),
+    }),
 ]);
+
+// Compile-time checks against the types generated from protocol.go. Notes sent
+// must match exactly; parsed types may differ in representation (e.g. dates),
+// so those must at least have the same fields.
+type Equal<A, B> = [A] extends [B] ? ([B] extends [A] ? true : false) : false;
+type Matches<A, B> = Equal<A, DeepReadonly<B>>;
+type SameKeys<A, B> = Equal<keyof A, keyof B>;
+type Assert<T extends true[]> = T;
+
+export type GeneratedChecks = Assert<
+    [
+        [ClientNote] extends [generated.ClientNote] ? true : false,
+        Equal<ClientNote['method'], generated.ClientMethod>,
+        Equal<GameMode, generated.Mode>,
+        Equal<UndoRule, generated.UndoRule>,
+        Equal<ResultCode, generated.ResultCode>,
+        Equal<ServerNote['method'], generated.ServerMethod>,
+        Equal<CloseEventData, generated.CloseEvent>,
+        Matches<InviteResponse, generated.InviteResponse>,
+        Matches<LobbyRoom, generated.LobbyRoom>,
+        Matches<StateView, generated.StateView>,
+        Matches<StatePlayer, generated.StatePlayer>,
+        Matches<StateWordList, generated.StateWordList>,
+        Matches<StateDuet, generated.StateDuet>,
+        SameKeys<RoomResponse, generated.RoomResponse>,
+        SameKeys<RoomsResponse, generated.RoomsResponse>,
+        SameKeys<TimeResponse, generated.TimeResponse>,
+        SameKeys<StateTile, generated.StateTile>,
+        SameKeys<StateTimer, generated.StateTimer>,
+        SameKeys<StateClue, generated.StateClue>,
+        SameKeys<StateEvent, generated.StateEvent>,
+        SameKeys<NonNullable<StateEvent['tile']>, generated.StateEventTile>,
+        SameKeys<RoomState, generated.RoomState>,
+        SameKeys<NonNullable<RoomState['undo']>, generated.StateUndo>,
+        SameKeys<State, generated.State>,
+        SameKeys<ChatMessage, generated.ChatMessage>,
+        SameKeys<PatchOp, generated.PatchOp>
+    ]
+>;
@@ -0,0 +1,69 @@
+import { PatchOp } from '.';
+
+// See patch.go. Patches are applied to the raw JSON of a previous state, before
+// it's parsed, as parsing converts some values (like dates).
+
+function unescapePointer(s: string): string {
+    return s.replace(/~1/g, '/').replace(/~0/g, '~');
+}
+
+function clone(v: unknown): unknown {
+    return v === undefined ? v : JSON.parse(JSON.stringify(v));
+}
+
+// applyPatch returns a copy of doc with the operations applied, or throws if
+// they don't apply.
+export function applyPatch(doc: unknown, ops: readonly PatchOp[]): unknown {
+    doc = clone(doc);
+
+    for (const op of ops) {
+        const value = clone(op.value);
+
+        if (op.path === '') {
+            doc = value;
+            continue;
+        }
+
+        const parts = op.path.slice(1).split('/').map(unescapePointer);
+        const last = parts[parts.length - 1];
+
+        let parent: any = doc;
+        for (const part of parts.slice(0, -1)) {
+            if (typeof parent !== 'object' || parent === null) {
+                throw new Error(`bad patch path ${op.path}`);
+            }
+            parent = parent[part];
+        }
+
+        if (typeof parent !== 'object' || parent === null) {
+            throw new Error(`bad patch path ${op.path}`);
+        }
+
+        if (Array.isArray(parent)) {
+            const i = parseInt(last, 10);
+            if (isNaN(i) || i < 0 || i > parent.length) {
+                throw new Error(`bad patch index ${op.path}`);
+            }
+
+            switch (op.op) {
+                case 'add':
+                    parent.splice(i, 0, value);
+                    break;
+                case 'remove':
+                    parent.splice(i, 1);
+                    break;
+                default:
+                    parent[i] = value;
+            }
+            continue;
+        }
+
+        if (op.op === 'remove') {
+            delete parent[last];
+        } else {
+            parent[last] = value;
+        }
+    }
+
+    return doc;
+}
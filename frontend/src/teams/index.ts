@@ -1,4 +1,4 @@
-import { blue, red } from '@material-ui/core/colors';
+import { blue, green, purple, red } from '@material-ui/core/colors';
 
 export type TeamHue = { [x in keyof typeof red]: string };
 
@@ -10,4 +10,6 @@ export interface TeamSpec {
 export const teamSpecs: TeamSpec[] = [
     { name: 'Red', hue: red },
     { name: 'Blue', hue: blue },
+    { name: 'Green', hue: green },
+    { name: 'Purple', hue: purple },
 ];
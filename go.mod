@@ -3,6 +3,7 @@ module github.com/zikaeroh/codies
 go 1.14
 
 require (
+	github.com/fxamacker/cbor/v2 v2.2.0
 	github.com/go-chi/chi v4.1.2+incompatible
 	github.com/jessevdk/go-flags v1.4.1-0.20181221193153-c0795c8afcf4
 	github.com/mailru/easyjson v0.7.6
@@ -15,7 +16,10 @@ require (
 	github.com/zikaeroh/ctxlog v0.0.0-20200613043947-8791c8613223
 	go.uber.org/atomic v1.7.0
 	go.uber.org/zap v1.16.0
+	golang.org/x/crypto v0.0.0-20201016220609-9e8e0b390897
 	golang.org/x/sync v0.0.0-20201020160332-67f06af15bc9
+	golang.org/x/time v0.0.0-20200630173020-3af7569d3a1e
+	gopkg.in/yaml.v2 v2.3.0
 	gotest.tools/v3 v3.0.3
 	nhooyr.io/websocket v1.8.6
 )
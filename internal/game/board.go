@@ -13,19 +13,42 @@ func (t Team) next(numTeams int) Team {
 
 type Tile struct {
 	// Immutable
-	Word    string
-	Team    Team
-	Neutral bool
-	Bomb    bool
+	Word    string `json:"word"`
+	Team    Team   `json:"team"`
+	Neutral bool   `json:"neutral,omitempty"`
+	Bomb    bool   `json:"bomb,omitempty"`
+
+	// Per-side key card roles, indexed by side. Only set in duet games.
+	Duet []DuetRole `json:"duet,omitempty"`
 
 	// Mutable
-	Revealed bool
+	Revealed bool   `json:"revealed,omitempty"`
+	Marked   []bool `json:"marked,omitempty"` // Duet sides whose key has shown this tile to be a bystander.
 }
 
 type Board struct {
 	Rows, Cols int
 	WordCounts []int
-	tiles      []*Tile // len(items)=rows*cols, access via items[row*rows + col]
+	tiles      []*Tile // len(tiles)=rows*cols, row-major; access via tiles[row*cols + col]
+}
+
+// pickWords picks n distinct words from the list at random.
+func pickWords(n int, words words.List, rand Rand) []string {
+	picked := make([]string, n)
+	seen := make(map[int]struct{}, n)
+
+	for i := range picked {
+		for {
+			j := rand.Intn(words.Len())
+			if _, ok := seen[j]; !ok {
+				seen[j] = struct{}{}
+				picked[i] = words.Get(j)
+				break
+			}
+		}
+	}
+
+	return picked
 }
 
 func newBoard(rows, cols int, words words.List, startingTeam Team, numTeams int, rand Rand) *Board {
@@ -39,26 +62,18 @@ func newBoard(rows, cols int, words words.List, startingTeam Team, numTeams int,
 		panic("invalid board dimension")
 	}
 
-	// Copy and rotate teams to give the first team the most words.
+	// Copy and rotate teams to give the starting team the most words, with
+	// the following teams (in turn order) getting successively fewer.
 	old := layout.teams
-	layout.teams = append([]int(nil), old[startingTeam:]...)
-	layout.teams = append(layout.teams, old[:startingTeam]...)
+	layout.teams = make([]int, numTeams)
+	for t := range layout.teams {
+		layout.teams[t] = old[(t-int(startingTeam)+numTeams)%numTeams]
+	}
 	wordCounts := append([]int(nil), layout.teams...)
 
 	items := make([]*Tile, n)
-	seen := make(map[int]struct{}, n)
-
-	for i := range items {
-		var w string
-		for {
-			j := rand.Intn(words.Len())
-			if _, ok := seen[j]; !ok {
-				seen[j] = struct{}{}
-				w = words.Get(j)
-				break
-			}
-		}
 
+	for i, w := range pickWords(n, words, rand) {
 		item := &Tile{Word: w}
 
 	ItemSwitch:
@@ -100,16 +115,52 @@ func newBoard(rows, cols int, words words.List, startingTeam Team, numTeams int,
 	}
 }
 
+// InBounds returns true if the coordinates refer to a tile on the board.
+func (b *Board) InBounds(row, col int) bool {
+	return row >= 0 && col >= 0 && row < b.Rows && col < b.Cols
+}
+
+// Index converts coordinates into an index in row-major order. If the
+// coordinates are out of bounds, false is returned.
+func (b *Board) Index(row, col int) (int, bool) {
+	if !b.InBounds(row, col) {
+		return -1, false
+	}
+	return row*b.Cols + col, true
+}
+
+// Coords converts a row-major index into coordinates; it is the inverse of Index.
+func (b *Board) Coords(i int) (row, col int) {
+	return i / b.Cols, i % b.Cols
+}
+
+// Get returns the tile at the given coordinates, or nil if out of bounds.
 func (b *Board) Get(row, col int) *Tile {
-	switch {
-	case row < 0:
-	case col < 0:
-	case row >= b.Rows:
-	case col >= b.Cols:
-	default:
-		i := row*b.Rows + col
-		return b.tiles[i]
+	i, ok := b.Index(row, col)
+	if !ok {
+		return nil
 	}
+	return b.tiles[i]
+}
 
-	return nil
+// Set replaces the tile at the given coordinates. If the coordinates are out
+// of bounds, false is returned and the board is unchanged.
+func (b *Board) Set(row, col int, tile *Tile) bool {
+	i, ok := b.Index(row, col)
+	if !ok {
+		return false
+	}
+	b.tiles[i] = tile
+	return true
+}
+
+// Tiles calls fn for each tile in row-major order, stopping early if fn
+// returns false.
+func (b *Board) Tiles(fn func(row, col int, tile *Tile) bool) {
+	for i, tile := range b.tiles {
+		row, col := b.Coords(i)
+		if !fn(row, col, tile) {
+			return
+		}
+	}
 }
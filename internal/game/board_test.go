@@ -0,0 +1,133 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+	"testing/quick"
+
+	"github.com/zikaeroh/codies/internal/words/static"
+	"gotest.tools/v3/assert"
+)
+
+// layoutDims returns all allowed board dimensions for a board size.
+func layoutDims(boardSize int) (dims [][2]int) {
+	for rows := 1; rows <= boardSize; rows++ {
+		if boardSize%rows != 0 {
+			continue
+		}
+
+		cols := boardSize / rows
+		if diff := rows - cols; diff >= -1 && diff <= 1 {
+			dims = append(dims, [2]int{rows, cols})
+		}
+	}
+	return dims
+}
+
+func TestLayoutDims(t *testing.T) {
+	for key := range layouts {
+		assert.Assert(t, len(layoutDims(key.boardSize)) > 0, "board size %d", key.boardSize)
+	}
+}
+
+func TestBoardProperties(t *testing.T) {
+	for key, layout := range layouts {
+		for _, dims := range layoutDims(key.boardSize) {
+			key, layout, rows, cols := key, layout, dims[0], dims[1]
+
+			prop := func(seed int64, start uint8) bool {
+				startingTeam := Team(int(start) % key.numTeams)
+				rng := rand.New(rand.NewSource(seed)) //nolint:gosec
+				b := newBoard(rows, cols, static.Default, startingTeam, key.numTeams, rng)
+
+				if b.Rows != rows || b.Cols != cols || len(b.tiles) != rows*cols {
+					return false
+				}
+
+				// The starting team gets the most words; the others follow in turn order.
+				for i, count := range b.WordCounts {
+					if count != layout.teams[(i-int(startingTeam)+key.numTeams)%key.numTeams] {
+						return false
+					}
+				}
+
+				bombs, neutrals := 0, 0
+				teams := make([]int, key.numTeams)
+				seen := make(map[string]bool, len(b.tiles))
+				next := 0
+
+				ok := true
+				b.Tiles(func(row, col int, tile *Tile) bool {
+					i, inBounds := b.Index(row, col)
+					r, c := b.Coords(i)
+
+					switch {
+					case !inBounds, i != next, r != row, c != col, b.Get(row, col) != tile, seen[tile.Word]:
+						ok = false
+						return false
+					}
+
+					next++
+					seen[tile.Word] = true
+
+					switch {
+					case tile.Bomb:
+						bombs++
+					case tile.Neutral:
+						neutrals++
+					default:
+						teams[tile.Team]++
+					}
+					return true
+				})
+
+				if !ok || next != rows*cols || bombs != layout.bomb || neutrals != layout.neutral {
+					return false
+				}
+
+				for i, count := range teams {
+					if count != b.WordCounts[i] {
+						return false
+					}
+				}
+
+				return true
+			}
+
+			err := quick.Check(prop, nil)
+			assert.NilError(t, err, "%dx%d, %d teams", rows, cols, key.numTeams)
+		}
+	}
+}
+
+func TestBoardBounds(t *testing.T) {
+	for key := range layouts {
+		for _, dims := range layoutDims(key.boardSize) {
+			rows, cols := dims[0], dims[1]
+			rng := rand.New(rand.NewSource(1)) //nolint:gosec
+			b := newBoard(rows, cols, static.Default, 0, key.numTeams, rng)
+
+			outside := [][2]int{
+				{-1, 0},
+				{0, -1},
+				{rows, 0},
+				{0, cols},
+				{rows, cols},
+				{rows - 1, cols},
+				{rows, cols - 1},
+			}
+
+			for _, rc := range outside {
+				assert.Assert(t, b.Get(rc[0], rc[1]) == nil, "%dx%d: %v", rows, cols, rc)
+				assert.Assert(t, !b.Set(rc[0], rc[1], &Tile{}), "%dx%d: %v", rows, cols, rc)
+				_, ok := b.Index(rc[0], rc[1])
+				assert.Assert(t, !ok, "%dx%d: %v", rows, cols, rc)
+			}
+
+			tile := &Tile{Word: "SET"}
+			assert.Assert(t, b.Set(rows-1, cols-1, tile))
+			assert.Equal(t, b.Get(rows-1, cols-1), tile)
+			assert.Equal(t, b.tiles[len(b.tiles)-1], tile)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package game
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const maxClueLen = 32 // In runes.
+
+// Clue is a clue given by a spymaster for the current turn.
+type Clue struct {
+	Team      Team   `json:"team"`
+	Word      string `json:"word"`
+	Count     int    `json:"count"`
+	Unlimited bool   `json:"unlimited"`
+
+	// Guesses remaining this turn. Only meaningful if the clue is limited.
+	GuessesLeft int `json:"guessesLeft"`
+}
+
+// Limited returns true if the clue limits the number of guesses. Per the
+// rules, clues for zero words allow unlimited guesses, like unlimited clues.
+func (c *Clue) Limited() bool {
+	return !c.Unlimited && c.Count > 0
+}
+
+// GiveClue gives a clue for the current turn. In classic games, only the
+// current team's spymaster may give a clue; in duet games, any player on the
+// side whose key card is in play may. Only one clue may be given per turn,
+// and the clue may not be a word which is still hidden on the board.
+//
+// Once a clue has been given, the guessing team may make at most one more
+// guess than the clue's count before their turn ends. Turns without a clue
+// are not limited.
+func (r *Room) GiveClue(id PlayerID, word string, count int, unlimited bool) error {
+	if r.Over() {
+		return ErrGameOver
+	}
+
+	p := r.Players[id]
+	if p == nil {
+		return ErrUnknownPlayer
+	}
+
+	if r.Duet != nil {
+		if p.Team != r.duetKeySide() {
+			return ErrNotYourTurn
+		}
+	} else {
+		if p.Team != r.Turn {
+			return ErrNotYourTurn
+		}
+		if !p.Spymaster {
+			return ErrNotAllowed
+		}
+	}
+
+	if r.Clue != nil {
+		return ErrNotAllowed
+	}
+
+	word = strings.ToUpper(strings.TrimSpace(word))
+	if word == "" || utf8.RuneCountInString(word) > maxClueLen {
+		return ErrInvalidClue
+	}
+
+	if count < 0 || count > r.Board.Rows*r.Board.Cols {
+		return ErrInvalidClue
+	}
+
+	hidden := false
+	r.Board.Tiles(func(_, _ int, tile *Tile) bool {
+		hidden = !tile.Revealed && tile.Word == word
+		return !hidden
+	})
+	if hidden {
+		return ErrInvalidClue
+	}
+
+	r.Clue = &Clue{
+		Team:        r.Turn,
+		Word:        word,
+		Count:       count,
+		Unlimited:   unlimited,
+		GuessesLeft: count + 1,
+	}
+
+	clue := *r.Clue
+	r.logEvent(id, &Event{Type: EventClue, Clue: &clue})
+
+	r.Version++
+	return nil
+}
+
+// useGuess counts a correct guess against the current clue, ending the turn
+// if no guesses are left.
+func (r *Room) useGuess() {
+	if r.Clue == nil || !r.Clue.Limited() {
+		return
+	}
+
+	r.Clue.GuessesLeft--
+	if r.Clue.GuessesLeft <= 0 {
+		r.nextTurn()
+	}
+}
@@ -0,0 +1,103 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func newClueTestRoom(t *testing.T) (r *Room, spymaster, guesser PlayerID) {
+	t.Helper()
+
+	r = newTestRoom(t, 2)
+	spymaster = PlayerID("spymaster")
+	r.AddPlayer(spymaster, spymaster)
+	r.ChangeTeam(spymaster, r.Turn)
+	r.ChangeRole(spymaster, true)
+	return r, spymaster, turnPlayer(r)
+}
+
+func ownWord(t *testing.T, r *Room) (row, col int) {
+	t.Helper()
+	return findTile(t, r.Board, func(tile *Tile) bool {
+		return !tile.Revealed && !tile.Neutral && !tile.Bomb && tile.Team == r.Turn
+	})
+}
+
+func TestGiveClue(t *testing.T) {
+	r, spymaster, guesser := newClueTestRoom(t)
+	team := r.Turn
+
+	// Only the current team's spymaster may give a clue.
+	r.GiveClue(guesser, "ocean", 2, false)
+	assert.Assert(t, r.Clue == nil)
+
+	r.GiveClue(spymaster, "ocean", 2, false)
+	assert.Assert(t, r.Clue != nil)
+	assert.Equal(t, r.Clue.Word, "OCEAN")
+	assert.Equal(t, r.Clue.GuessesLeft, 3)
+
+	// Only one clue per turn.
+	r.GiveClue(spymaster, "other", 1, false)
+	assert.Equal(t, r.Clue.Word, "OCEAN")
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, r.Turn, team)
+		row, col := ownWord(t, r)
+		r.Reveal(guesser, row, col)
+	}
+
+	assert.Assert(t, r.Turn != team)
+	assert.Assert(t, r.Clue == nil)
+}
+
+func TestGiveClueUnlimited(t *testing.T) {
+	for _, unlimited := range []bool{false, true} {
+		r, spymaster, guesser := newClueTestRoom(t)
+		team := r.Turn
+
+		count := 0
+		if unlimited {
+			count = 3
+		}
+
+		r.GiveClue(spymaster, "ocean", count, unlimited)
+		assert.Assert(t, r.Clue != nil)
+		assert.Assert(t, !r.Clue.Limited())
+
+		for i := 0; i < 6; i++ {
+			row, col := ownWord(t, r)
+			r.Reveal(guesser, row, col)
+		}
+
+		assert.Equal(t, r.Turn, team)
+	}
+}
+
+func TestGiveClueHiddenWord(t *testing.T) {
+	r, spymaster, guesser := newClueTestRoom(t)
+
+	row, col := ownWord(t, r)
+	word := r.Board.Get(row, col).Word
+
+	r.GiveClue(spymaster, word, 1, false)
+	assert.Assert(t, r.Clue == nil)
+
+	r.Reveal(guesser, row, col)
+	r.GiveClue(spymaster, word, 1, false)
+	assert.Assert(t, r.Clue != nil)
+}
+
+func TestGiveClueInvalid(t *testing.T) {
+	r, spymaster, _ := newClueTestRoom(t)
+
+	assert.Equal(t, r.GiveClue(spymaster, "  ", 1, false), ErrInvalidClue)
+	assert.Equal(t, r.GiveClue(spymaster, "ocean", -1, false), ErrInvalidClue)
+	assert.Equal(t, r.GiveClue(spymaster, "ocean", 26, false), ErrInvalidClue)
+	assert.Equal(t, r.GiveClue(spymaster, strings.Repeat("é", maxClueLen+1), 1, false), ErrInvalidClue)
+	assert.Assert(t, r.Clue == nil)
+
+	// The limit counts characters, not bytes.
+	assert.NilError(t, r.GiveClue(spymaster, strings.Repeat("é", maxClueLen), 1, false))
+}
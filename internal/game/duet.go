@@ -0,0 +1,140 @@
+package game
+
+import (
+	"github.com/zikaeroh/codies/internal/words"
+)
+
+// Mode selects the rules used for a game.
+type Mode string
+
+const (
+	// ModeClassic is the competitive game, where each team's spymaster gives
+	// clues to their own team.
+	ModeClassic Mode = "classic"
+
+	// ModeDuet is the cooperative two-sided game. Each side has its own key
+	// card; the side which is not guessing gives clues from its key card.
+	ModeDuet Mode = "duet"
+)
+
+// DuetRole is a tile's identity on one side's duet key card.
+type DuetRole int
+
+const (
+	DuetBystander DuetRole = iota
+	DuetAgent
+	DuetAssassin
+)
+
+const (
+	duetSides       = 2
+	duetTimerTokens = 9
+)
+
+// Duet is the shared state of a duet game.
+type Duet struct {
+	TimerTokens int  `json:"timerTokens"` // Turns left; one is used at the end of every turn.
+	Won         bool `json:"won"`
+	Lost        bool `json:"lost"`
+}
+
+func newDuetBoard(rows, cols int, words words.List, rand Rand) *Board {
+	n := rows * cols
+	layout, ok := duetLayouts[n]
+	if !ok {
+		panic("invalid board dimension")
+	}
+
+	items := make([]*Tile, 0, n)
+	wordCounts := make([]int, duetSides)
+	picked := pickWords(n, words, rand)
+
+	for _, entry := range layout {
+		for i := 0; i < entry.count; i++ {
+			item := &Tile{
+				Word:   picked[len(items)],
+				Duet:   append([]DuetRole(nil), entry.roles[:]...),
+				Marked: make([]bool, duetSides),
+			}
+
+			for side, role := range entry.roles {
+				if role == DuetAgent {
+					wordCounts[side]++
+				}
+			}
+
+			items = append(items, item)
+		}
+	}
+
+	if len(items) != n {
+		panic("unreachable")
+	}
+
+	rand.Shuffle(len(items), func(i, j int) {
+		items[i], items[j] = items[j], items[i]
+	})
+
+	return &Board{
+		Rows:       rows,
+		Cols:       cols,
+		WordCounts: wordCounts,
+		tiles:      items,
+	}
+}
+
+// DuetKeySide returns the side whose key card is in play while the given side
+// is guessing, i.e. the side giving clues.
+func DuetKeySide(guessing Team) Team {
+	return guessing.next(duetSides)
+}
+
+func (r *Room) duetKeySide() Team {
+	return DuetKeySide(r.Turn)
+}
+
+func (r *Room) duetReveal(tile *Tile) {
+	key := r.duetKeySide()
+
+	switch tile.Duet[key] {
+	case DuetAgent:
+		tile.Revealed = true
+
+		won := true
+		for side, role := range tile.Duet {
+			if role == DuetAgent {
+				r.Board.WordCounts[side]--
+			}
+			if r.Board.WordCounts[side] != 0 {
+				won = false
+			}
+		}
+
+		r.Duet.Won = won
+		if !won {
+			r.useGuess()
+		}
+
+	case DuetBystander:
+		tile.Marked[key] = true
+		r.nextTurn()
+
+	case DuetAssassin:
+		tile.Revealed = true
+		r.Duet.Lost = true
+	}
+}
+
+func (r *Room) duetEndTurn() {
+	r.Duet.TimerTokens--
+	if r.Duet.TimerTokens <= 0 {
+		r.Duet.Lost = true
+		return
+	}
+
+	// The guessing side gives the next clue, unless all of the agents on
+	// their key card have already been found.
+	if r.Board.WordCounts[r.Turn] != 0 {
+		r.Turn = r.Turn.next(duetSides)
+	}
+}
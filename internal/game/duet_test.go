@@ -0,0 +1,131 @@
+package game
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func newDuetTestRoom(t *testing.T) *Room {
+	t.Helper()
+
+	r := newTestRoom(t, 3)
+	r.ChangeMode("", ModeDuet)
+	assert.Equal(t, r.Mode, ModeDuet)
+	assert.Equal(t, len(r.Teams), duetSides)
+	assert.Assert(t, r.Duet != nil)
+	return r
+}
+
+func TestDuetBoard(t *testing.T) {
+	r := newDuetTestRoom(t)
+
+	agents := make([]int, duetSides)
+	r.Board.Tiles(func(_, _ int, tile *Tile) bool {
+		assert.Equal(t, len(tile.Duet), duetSides)
+		assert.Equal(t, len(tile.Marked), duetSides)
+		for side, role := range tile.Duet {
+			if role == DuetAgent {
+				agents[side]++
+			}
+		}
+		return true
+	})
+
+	assert.DeepEqual(t, agents, r.Board.WordCounts)
+	assert.Equal(t, r.Duet.TimerTokens, duetTimerTokens)
+}
+
+func TestDuetNoSpymasters(t *testing.T) {
+	r := newDuetTestRoom(t)
+
+	id := turnPlayer(r)
+	r.ChangeRole(id, true)
+	assert.Assert(t, !r.Players[id].Spymaster)
+
+	r.ChangeNumTeams("", 3)
+	assert.Equal(t, len(r.Teams), duetSides)
+}
+
+func TestDuetReveal(t *testing.T) {
+	r := newDuetTestRoom(t)
+
+	guesser := r.Turn
+	key := r.duetKeySide()
+
+	row, col := findTile(t, r.Board, func(tile *Tile) bool {
+		return tile.Duet[key] == DuetAgent && tile.Duet[guesser] == DuetAgent
+	})
+	r.Reveal(turnPlayer(r), row, col)
+	assert.Assert(t, r.Board.Get(row, col).Revealed)
+	assert.Equal(t, r.Board.WordCounts[key], 8)
+	assert.Equal(t, r.Board.WordCounts[guesser], 8)
+	assert.Equal(t, r.Turn, guesser)
+
+	row, col = findTile(t, r.Board, func(tile *Tile) bool {
+		return tile.Duet[key] == DuetBystander && tile.Duet[guesser] == DuetAgent
+	})
+	r.Reveal(turnPlayer(r), row, col)
+	tile := r.Board.Get(row, col)
+	assert.Assert(t, !tile.Revealed)
+	assert.Assert(t, tile.Marked[key])
+	assert.Equal(t, r.Duet.TimerTokens, duetTimerTokens-1)
+	assert.Equal(t, r.Turn, key)
+
+	// The tile can't be guessed again against the key which marked it.
+	r.EndTurn(turnPlayer(r))
+	assert.Equal(t, r.Turn, guesser)
+	assert.Equal(t, r.Reveal(turnPlayer(r), row, col), ErrInvalidTile)
+	assert.Assert(t, !tile.Revealed)
+	assert.Equal(t, r.Duet.TimerTokens, duetTimerTokens-2)
+	r.EndTurn(turnPlayer(r))
+
+	// The tile is an agent on the other key card, so it can still be found.
+	r.Reveal(turnPlayer(r), row, col)
+	assert.Assert(t, tile.Revealed)
+	assert.Assert(t, !r.Over())
+}
+
+func TestDuetAssassin(t *testing.T) {
+	r := newDuetTestRoom(t)
+
+	key := r.duetKeySide()
+	row, col := findTile(t, r.Board, func(tile *Tile) bool { return tile.Duet[key] == DuetAssassin })
+	r.Reveal(turnPlayer(r), row, col)
+
+	assert.Assert(t, r.Duet.Lost)
+	assert.Assert(t, r.Over())
+	assert.Assert(t, r.Winner == nil)
+}
+
+func TestDuetOutOfTime(t *testing.T) {
+	r := newDuetTestRoom(t)
+
+	for i := 0; i < duetTimerTokens-1; i++ {
+		r.EndTurn(turnPlayer(r))
+		assert.Assert(t, !r.Over())
+	}
+
+	r.EndTurn(turnPlayer(r))
+	assert.Assert(t, r.Duet.Lost)
+}
+
+func TestDuetWin(t *testing.T) {
+	r := newDuetTestRoom(t)
+
+	for !r.Over() {
+		key := r.duetKeySide()
+		if r.Board.WordCounts[key] == 0 {
+			r.EndTurn(turnPlayer(r))
+			continue
+		}
+
+		row, col := findTile(t, r.Board, func(tile *Tile) bool {
+			return !tile.Revealed && tile.Duet[key] == DuetAgent
+		})
+		r.Reveal(turnPlayer(r), row, col)
+	}
+
+	assert.Assert(t, r.Duet.Won)
+	assert.Assert(t, !r.Duet.Lost)
+}
@@ -5,10 +5,64 @@ type layoutKey struct {
 	numTeams  int
 }
 
+func hasLayout(mode Mode, rows, cols, numTeams int) bool {
+	if mode == ModeDuet {
+		_, ok := duetLayouts[rows*cols]
+		return ok && numTeams == duetSides
+	}
+
+	_, ok := layouts[layoutKey{boardSize: rows * cols, numTeams: numTeams}]
+	return ok
+}
+
 var layouts = map[layoutKey]struct {
 	bomb    int
 	neutral int
 	teams   []int
 }{
+	// 4x4
+	{16, 2}: {1, 4, []int{6, 5}},
+	{16, 3}: {1, 3, []int{5, 4, 3}},
+	{16, 4}: {1, 1, []int{5, 4, 3, 2}},
+
+	// 5x5
 	{25, 2}: {1, 7, []int{9, 8}},
+	{25, 3}: {1, 6, []int{7, 6, 5}},
+	{25, 4}: {1, 2, []int{7, 6, 5, 4}},
+
+	// 5x6
+	{30, 2}: {1, 8, []int{11, 10}},
+	{30, 3}: {1, 8, []int{8, 7, 6}},
+	{30, 4}: {1, 3, []int{8, 7, 6, 5}},
+
+	// 6x6
+	{36, 2}: {1, 10, []int{13, 12}},
+	{36, 3}: {1, 8, []int{10, 9, 8}},
+	{36, 4}: {1, 5, []int{9, 8, 7, 6}},
+
+	// 7x7
+	{49, 2}: {2, 14, []int{17, 16}},
+	{49, 3}: {2, 11, []int{13, 12, 11}},
+	{49, 4}: {2, 5, []int{12, 11, 10, 9}},
+}
+
+type duetLayoutEntry struct {
+	roles [duetSides]DuetRole
+	count int
+}
+
+// Duet layouts list how many tiles have each combination of roles across the
+// two key cards.
+var duetLayouts = map[int][]duetLayoutEntry{
+	25: {
+		{[duetSides]DuetRole{DuetAgent, DuetAgent}, 3},
+		{[duetSides]DuetRole{DuetAgent, DuetBystander}, 5},
+		{[duetSides]DuetRole{DuetBystander, DuetAgent}, 5},
+		{[duetSides]DuetRole{DuetAgent, DuetAssassin}, 1},
+		{[duetSides]DuetRole{DuetAssassin, DuetAgent}, 1},
+		{[duetSides]DuetRole{DuetAssassin, DuetAssassin}, 1},
+		{[duetSides]DuetRole{DuetAssassin, DuetBystander}, 1},
+		{[duetSides]DuetRole{DuetBystander, DuetAssassin}, 1},
+		{[duetSides]DuetRole{DuetBystander, DuetBystander}, 7},
+	},
 }
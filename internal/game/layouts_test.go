@@ -1,12 +1,32 @@
 package game
 
 import (
+	"math/rand"
 	"sort"
 	"testing"
 
+	"github.com/zikaeroh/codies/internal/words/static"
 	"gotest.tools/v3/assert"
 )
 
+func TestStartingTeamWordCounts(t *testing.T) {
+	rand := rand.New(rand.NewSource(1)) //nolint:gosec
+
+	for _, numTeams := range []int{2, 3, 4} {
+		want := layouts[layoutKey{boardSize: 25, numTeams: numTeams}].teams
+
+		for start := 0; start < numTeams; start++ {
+			b := newBoard(5, 5, static.Default, Team(start), numTeams, rand)
+
+			// The starting team gets the extra word, and each team after it
+			// in turn order one fewer than the last.
+			for i, count := range want {
+				assert.Equal(t, b.WordCounts[(start+i)%numTeams], count, "numTeams=%d start=%d", numTeams, start)
+			}
+		}
+	}
+}
+
 func TestLayouts(t *testing.T) {
 	for key, layout := range layouts {
 		assert.Equal(t, len(layout.teams), key.numTeams)
@@ -23,3 +43,31 @@ func TestLayouts(t *testing.T) {
 		}))
 	}
 }
+
+func TestDuetLayouts(t *testing.T) {
+	for size, layout := range duetLayouts {
+		sum := 0
+		agents := make([]int, duetSides)
+		assassins := make([]int, duetSides)
+
+		for _, entry := range layout {
+			sum += entry.count
+			for side, role := range entry.roles {
+				switch role {
+				case DuetAgent:
+					agents[side] += entry.count
+				case DuetAssassin:
+					assassins[side] += entry.count
+				}
+			}
+		}
+
+		assert.Equal(t, sum, size)
+
+		// Both key cards must be equally difficult.
+		for side := 1; side < duetSides; side++ {
+			assert.Equal(t, agents[side], agents[0])
+			assert.Equal(t, assassins[side], assassins[0])
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package game
+
+import "time"
+
+// EventType identifies the kind of an event in a game's log.
+type EventType string
+
+const (
+	EventNewGame        EventType = "newGame"
+	EventReveal         EventType = "reveal"
+	EventClue           EventType = "clue"
+	EventEndTurn        EventType = "endTurn"
+	EventChangeTeam     EventType = "changeTeam"
+	EventRandomizeTeams EventType = "randomizeTeams"
+	EventChangeRole     EventType = "changeRole"
+	EventJoin           EventType = "join"
+	EventLeave          EventType = "leave"
+	EventChangeNickname EventType = "changeNickname"
+	EventUndo           EventType = "undo"
+)
+
+// Event is an entry in a game's log.
+type Event struct {
+	Type EventType `json:"type"`
+	Time time.Time `json:"time"`
+	Turn Team      `json:"turn"` // The team (or duet side) whose turn it was.
+
+	// The player who caused the event, if any. Turns ended by the turn timer
+	// have no player. The nickname is recorded as the player may leave.
+	Player   PlayerID `json:"player,omitempty"`
+	Nickname string   `json:"nickname,omitempty"`
+
+	// EventReveal
+	Row  int   `json:"row,omitempty"`
+	Col  int   `json:"col,omitempty"`
+	Tile *Tile `json:"tile,omitempty"` // Copy of the tile's identity; its mutable fields are unset.
+
+	// EventClue
+	Clue *Clue `json:"clue,omitempty"`
+
+	// EventChangeTeam, EventJoin
+	Team Team `json:"team,omitempty"`
+
+	// EventRandomizeTeams
+	Teams [][]PlayerID `json:"teams,omitempty"`
+
+	// EventChangeRole
+	Spymaster bool `json:"spymaster,omitempty"`
+
+	// EventUndo, and EventReveal when reveals are voted on.
+	Undone *Event   `json:"undone,omitempty"` // The reveal or turn end which was undone.
+	Voters []string `json:"voters,omitempty"` // Nicknames of the players who agreed.
+}
+
+// maxLogEvents is the most events a game's log holds. The log is sent with
+// every state, so players who rejoin or rename over and over mustn't be able
+// to grow it without bound; once it's full, later events go unlogged.
+const maxLogEvents = 500
+
+// logFull reports whether the log is full, and so may be missing events.
+func (r *Room) logFull() bool {
+	return len(r.Log) >= maxLogEvents
+}
+
+// logEvent appends an event caused by the player (if any) to the log. It must
+// be called before the event modifies the game, so that the current turn is
+// recorded. Events which change a player's presence or nickname are logged
+// once the player has been updated, so that the new nickname is recorded.
+func (r *Room) logEvent(id PlayerID, e *Event) {
+	if r.logFull() {
+		return
+	}
+
+	e.Time = r.now()
+	e.Turn = r.Turn
+
+	if p := r.Players[id]; p != nil {
+		e.Player = p.ID
+		e.Nickname = p.Nickname
+	}
+
+	r.Log = append(r.Log, e)
+}
+
+func copyTile(tile *Tile) *Tile {
+	return &Tile{
+		Word:    tile.Word,
+		Team:    tile.Team,
+		Neutral: tile.Neutral,
+		Bomb:    tile.Bomb,
+		Duet:    tile.Duet,
+	}
+}
+
+func copyTeams(teams [][]PlayerID) [][]PlayerID {
+	c := make([][]PlayerID, len(teams))
+	for i, team := range teams {
+		c[i] = append([]PlayerID(nil), team...)
+	}
+	return c
+}
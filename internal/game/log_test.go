@@ -0,0 +1,111 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestLog(t *testing.T) {
+	r, spymaster, guesser := newClueTestRoom(t)
+
+	now := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	r.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	// Deal a game which the spymaster's team starts.
+	for seed := int64(0); r.Log[0].Player != guesser || r.Turn != r.Players[spymaster].Team; seed++ {
+		assert.NilError(t, r.NewSeededGame(guesser, seed))
+	}
+	r.ChangeRole(spymaster, true)
+
+	team := r.Turn
+	spymasterTeam := r.Players[spymaster].Team
+
+	r.GiveClue(spymaster, "ocean", 0, true)
+	row, col := findTile(t, r.Board, func(tile *Tile) bool { return tile.Neutral })
+	r.Reveal(guesser, row, col)
+	r.ForceEndTurn()
+	r.ChangeTeam(spymaster, spymasterTeam.next(2))
+	r.RandomizeTeams(guesser)
+	r.EndTurn("unknown")
+
+	want := []struct {
+		typ    EventType
+		player PlayerID
+	}{
+		{EventNewGame, guesser},
+		{EventChangeRole, spymaster},
+		{EventClue, spymaster},
+		{EventReveal, guesser},
+		{EventEndTurn, ""},
+		{EventChangeTeam, spymaster},
+		{EventRandomizeTeams, guesser},
+	}
+
+	assert.Equal(t, len(r.Log), len(want))
+
+	for i, w := range want {
+		e := r.Log[i]
+		assert.Equal(t, e.Type, w.typ, "event %d", i)
+		assert.Equal(t, e.Player, w.player, "event %d", i)
+		if i > 0 {
+			assert.Assert(t, e.Time.After(r.Log[i-1].Time), "event %d", i)
+		}
+	}
+
+	assert.Assert(t, r.Log[1].Spymaster)
+	assert.Equal(t, r.Log[2].Clue.Word, "OCEAN")
+	assert.Equal(t, r.Log[3].Turn, team)
+	assert.Equal(t, r.Log[3].Tile.Word, r.Board.Get(row, col).Word)
+	assert.Assert(t, r.Log[3].Tile.Neutral)
+	assert.Equal(t, r.Log[4].Turn, team.next(2))
+	assert.Equal(t, r.Log[5].Team, spymasterTeam.next(2))
+	assert.DeepEqual(t, r.Log[6].Teams, r.Teams)
+
+	assert.NilError(t, r.NewGame(""))
+	assert.Equal(t, len(r.Log), 1)
+}
+
+func TestLogPlayers(t *testing.T) {
+	r := newTestRoom(t, 2)
+
+	r.AddPlayer("z", "first")
+	r.AddPlayer("z", "second")
+	r.RemovePlayer("z")
+
+	events := r.Log[1:]
+	assert.Equal(t, len(events), 3)
+
+	assert.Equal(t, events[0].Type, EventJoin)
+	assert.Equal(t, events[0].Nickname, "first")
+	assert.Equal(t, events[1].Type, EventChangeNickname)
+	assert.Equal(t, events[1].Nickname, "second")
+	assert.Equal(t, events[2].Type, EventLeave)
+	assert.Equal(t, events[2].Nickname, "second")
+}
+
+func TestLogLimit(t *testing.T) {
+	r := newTestRoom(t, 2)
+
+	// Rejoining and renaming over and over doesn't grow the log forever.
+	for i := 0; i < maxLogEvents; i++ {
+		r.AddPlayer("z", "first")
+		r.AddPlayer("z", "second")
+		r.RemovePlayer("z")
+	}
+	assert.Equal(t, len(r.Log), maxLogEvents)
+
+	r.EndTurn(turnPlayer(r))
+	assert.Equal(t, len(r.Log), maxLogEvents)
+
+	// The replay can't be checked against the final state, as events are
+	// missing, but still plays back.
+	rep := r.Replay()
+	assert.Assert(t, rep.Final == nil)
+	_, err := Play(roundTrip(t, rep))
+	assert.NilError(t, err)
+}
@@ -1,12 +1,23 @@
 package game
 
-import "math/rand"
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"math/rand"
+)
 
 type Rand interface {
 	Intn(n int) int
+	Int63() int64
 	Shuffle(n int, swap func(i, j int))
 }
 
+// NewSeededRand returns a Rand which always produces the same results for
+// the same seed.
+func NewSeededRand(seed int64) Rand {
+	return rand.New(rand.NewSource(seed)) //nolint:gosec
+}
+
 type globalRand struct{}
 
 var _ Rand = globalRand{}
@@ -15,6 +26,16 @@ func (globalRand) Intn(n int) int {
 	return rand.Intn(n) //nolint:gosec
 }
 
+// Int63 returns an unpredictable seed. The key card is derived from the seed
+// and words which everyone can see, so a guessable seed would reveal it.
+func (globalRand) Int63() int64 {
+	var b [8]byte
+	if _, err := crand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return int64(binary.LittleEndian.Uint64(b[:]) >> 1)
+}
+
 func (globalRand) Shuffle(n int, swap func(i, j int)) {
 	rand.Shuffle(n, swap)
 }
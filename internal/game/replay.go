@@ -0,0 +1,320 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// replayFormat is the version of the replay format, to be incremented on
+// incompatible changes.
+const replayFormat = 1
+
+var (
+	ErrInvalidReplay  = errors.New("game: invalid replay")
+	ErrReplayMismatch = errors.New("game: replay does not match")
+)
+
+// Replay is a self-contained record of a game: the board as it was dealt
+// (including the key), the players at the start of the game, every event in
+// the game in order, and the state the game ended in.
+type Replay struct {
+	Format   int   `json:"format"`
+	Mode     Mode  `json:"mode"`
+	Rows     int   `json:"rows"`
+	Cols     int   `json:"cols"`
+	NumTeams int   `json:"numTeams"`
+	Turn     Team  `json:"turn"` // The starting team.
+	Seed     int64 `json:"seed"`
+
+	Board      []*Tile   `json:"board"` // Row-major.
+	WordCounts []int     `json:"wordCounts"`
+	Duet       *Duet     `json:"duet,omitempty"`
+	Players    []*Player `json:"players"`
+
+	Events []*Event      `json:"events"`
+	Final  *ReplayResult `json:"final,omitempty"`
+}
+
+// ReplayResult is the state of a game at the end of a replay.
+type ReplayResult struct {
+	Turn       Team    `json:"turn"`
+	Winner     *Team   `json:"winner,omitempty"`
+	Eliminated []bool  `json:"eliminated"`
+	Duet       *Duet   `json:"duet,omitempty"`
+	Clue       *Clue   `json:"clue,omitempty"`
+	WordCounts []int   `json:"wordCounts"`
+	Board      []*Tile `json:"board"`
+}
+
+// newReplay records the current game as it is now, without any events.
+func (r *Room) newReplay() *Replay {
+	rep := &Replay{
+		Format:     replayFormat,
+		Mode:       r.Mode,
+		Rows:       r.Board.Rows,
+		Cols:       r.Board.Cols,
+		NumTeams:   len(r.Teams),
+		Turn:       r.Turn,
+		Seed:       r.Seed,
+		Board:      copyTiles(r.Board.tiles),
+		WordCounts: append([]int(nil), r.Board.WordCounts...),
+		Duet:       copyDuet(r.Duet),
+		Players:    make([]*Player, 0, len(r.Players)),
+	}
+
+	for _, members := range r.Teams {
+		for _, id := range members {
+			p := *r.Players[id]
+			rep.Players = append(rep.Players, &p)
+		}
+	}
+
+	return rep
+}
+
+// Replay returns a replay of the current game up to now, or nil if no game
+// has been started. If the log filled up, the replay only covers the logged
+// events, so it has no final state to check against.
+func (r *Room) Replay() *Replay {
+	if r.start == nil {
+		return nil
+	}
+
+	rep := *r.start
+	rep.Events = append([]*Event(nil), r.Log...)
+	if !r.logFull() {
+		rep.Final = r.result()
+	}
+	return &rep
+}
+
+func (r *Room) result() *ReplayResult {
+	res := &ReplayResult{
+		Turn:       r.Turn,
+		Eliminated: append([]bool(nil), r.Eliminated...),
+		Duet:       copyDuet(r.Duet),
+		WordCounts: append([]int(nil), r.Board.WordCounts...),
+		Board:      copyTiles(r.Board.tiles),
+	}
+
+	if r.Winner != nil {
+		winner := *r.Winner
+		res.Winner = &winner
+	}
+
+	if r.Clue != nil {
+		clue := *r.Clue
+		res.Clue = &clue
+	}
+
+	return res
+}
+
+// Play re-simulates a replay, starting from its initial board and applying
+// each of its events in order. ErrReplayMismatch is returned if any event
+// does not have the recorded effect, or if the game does not end in the
+// recorded final state.
+func Play(rep *Replay) (*Room, error) {
+	if err := rep.validate(); err != nil {
+		return nil, err
+	}
+
+	r := NewRoom(nil)
+	r.Mode = rep.Mode
+	r.Rows = rep.Rows
+	r.Cols = rep.Cols
+	r.Turn = rep.Turn
+	r.Seed = rep.Seed
+	r.Teams = make([][]PlayerID, rep.NumTeams)
+	r.Eliminated = make([]bool, rep.NumTeams)
+	r.Duet = copyDuet(rep.Duet)
+	r.Board = &Board{
+		Rows:       rep.Rows,
+		Cols:       rep.Cols,
+		WordCounts: append([]int(nil), rep.WordCounts...),
+		tiles:      copyTiles(rep.Board),
+	}
+
+	for _, p := range rep.Players {
+		p := *p
+		r.Players[p.ID] = &p
+		r.Teams[p.Team] = append(r.Teams[p.Team], p.ID)
+	}
+
+	r.start = r.newReplay()
+
+	for i, e := range rep.Events {
+		if err := r.replayEvent(i, e); err != nil {
+			return nil, err
+		}
+	}
+
+	if rep.Final != nil && !reflect.DeepEqual(r.result(), rep.Final) {
+		return nil, fmt.Errorf("%w: final state differs", ErrReplayMismatch)
+	}
+
+	return r, nil
+}
+
+func (rep *Replay) validate() error {
+	if rep.Format != replayFormat {
+		return fmt.Errorf("%w: unknown format %d", ErrInvalidReplay, rep.Format)
+	}
+
+	if !hasLayout(rep.Mode, rep.Rows, rep.Cols, rep.NumTeams) {
+		return fmt.Errorf("%w: bad board configuration", ErrInvalidReplay)
+	}
+
+	if len(rep.Board) != rep.Rows*rep.Cols || len(rep.WordCounts) != rep.NumTeams {
+		return fmt.Errorf("%w: board does not match its size", ErrInvalidReplay)
+	}
+
+	if (rep.Mode == ModeDuet) != (rep.Duet != nil) {
+		return fmt.Errorf("%w: duet state does not match mode", ErrInvalidReplay)
+	}
+
+	for _, tile := range rep.Board {
+		if tile == nil {
+			return fmt.Errorf("%w: missing tile", ErrInvalidReplay)
+		}
+
+		if rep.Duet != nil && (len(tile.Duet) != duetSides || len(tile.Marked) != duetSides) {
+			return fmt.Errorf("%w: missing duet key", ErrInvalidReplay)
+		}
+	}
+
+	validTeam := func(t Team) bool {
+		return t >= 0 && int(t) < rep.NumTeams
+	}
+
+	if !validTeam(rep.Turn) {
+		return fmt.Errorf("%w: bad starting team", ErrInvalidReplay)
+	}
+
+	seen := make(map[PlayerID]bool, len(rep.Players))
+	for _, p := range rep.Players {
+		if p == nil || seen[p.ID] || !validTeam(p.Team) {
+			return fmt.Errorf("%w: bad player", ErrInvalidReplay)
+		}
+		seen[p.ID] = true
+	}
+
+	if len(rep.Events) == 0 || rep.Events[0] == nil || rep.Events[0].Type != EventNewGame {
+		return fmt.Errorf("%w: replay does not start with a new game", ErrInvalidReplay)
+	}
+
+	for _, e := range rep.Events {
+		if e == nil {
+			return fmt.Errorf("%w: missing event", ErrInvalidReplay)
+		}
+	}
+
+	return nil
+}
+
+// replayEvent applies the i-th event of a replay, checking that it is logged
+// exactly as recorded.
+func (r *Room) replayEvent(i int, e *Event) error {
+	n := len(r.Log)
+	r.now = func() time.Time { return e.Time }
+
+	switch e.Type {
+	case EventNewGame:
+		if i == 0 {
+			r.logEvent(e.Player, &Event{Type: EventNewGame})
+		}
+
+	case EventReveal:
+		// Votes aren't logged, only the reveal they led to.
+		if p, tile, err := r.revealable(e.Player, e.Row, e.Col); err == nil {
+			r.reveal(p, e.Row, e.Col, tile, e.Voters)
+		}
+
+	case EventClue:
+		if e.Clue != nil {
+			r.GiveClue(e.Player, e.Clue.Word, e.Clue.Count, e.Clue.Unlimited)
+		}
+
+	case EventEndTurn:
+		if e.Player == "" {
+			r.ForceEndTurn()
+		} else {
+			r.EndTurn(e.Player)
+		}
+
+	case EventChangeTeam:
+		r.ChangeTeam(e.Player, e.Team)
+
+	case EventRandomizeTeams:
+		if r.validTeams(e.Teams) {
+			r.setTeams(e.Player, copyTeams(e.Teams))
+		}
+
+	case EventChangeRole:
+		r.ChangeRole(e.Player, e.Spymaster)
+
+	case EventJoin:
+		if r.Players[e.Player] == nil {
+			r.AddPlayer(e.Player, e.Nickname)
+		}
+
+	case EventLeave:
+		r.RemovePlayer(e.Player)
+
+	case EventChangeNickname:
+		if r.Players[e.Player] != nil {
+			r.AddPlayer(e.Player, e.Nickname)
+		}
+
+	case EventUndo:
+		// Votes aren't logged, only the undo they led to.
+		if r.Undo != nil && r.Players[e.Player] != nil {
+			r.undo(e.Player, e.Voters)
+		}
+	}
+
+	if len(r.Log) != n+1 || !reflect.DeepEqual(r.Log[n], e) {
+		return fmt.Errorf("%w: event %d (%s)", ErrReplayMismatch, i, e.Type)
+	}
+
+	return nil
+}
+
+// validTeams checks that the teams contain every player exactly once.
+func (r *Room) validTeams(teams [][]PlayerID) bool {
+	if len(teams) != len(r.Teams) {
+		return false
+	}
+
+	seen := make(map[PlayerID]bool, len(r.Players))
+	for _, members := range teams {
+		for _, id := range members {
+			if r.Players[id] == nil || seen[id] {
+				return false
+			}
+			seen[id] = true
+		}
+	}
+
+	return len(seen) == len(r.Players)
+}
+
+func copyTiles(tiles []*Tile) []*Tile {
+	c := make([]*Tile, len(tiles))
+	for i, tile := range tiles {
+		t := *tile
+		t.Marked = append([]bool(nil), tile.Marked...)
+		c[i] = &t
+	}
+	return c
+}
+
+func copyDuet(d *Duet) *Duet {
+	if d == nil {
+		return nil
+	}
+	c := *d
+	return &c
+}
@@ -0,0 +1,133 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func roundTrip(t *testing.T, rep *Replay) *Replay {
+	t.Helper()
+
+	b, err := json.Marshal(rep)
+	assert.NilError(t, err)
+
+	var out Replay
+	assert.NilError(t, json.Unmarshal(b, &out))
+	return &out
+}
+
+func TestReplay(t *testing.T) {
+	r, spymaster, guesser := newClueTestRoom(t)
+	r.ChangeRole(spymaster, true)
+	r.AddPlayer("late", "late")
+
+	r.GiveClue(spymaster, "ocean", 2, false)
+	row, col := ownWord(t, r)
+	r.Reveal(guesser, row, col)
+	r.EndTurn(guesser)
+	r.RandomizeTeams(guesser)
+	r.ForceEndTurn()
+	r.ChangeRole(spymaster, false)
+	r.RemovePlayer("late")
+
+	for !r.Over() {
+		row, col := findTile(t, r.Board, func(tile *Tile) bool { return !tile.Revealed })
+		r.Reveal(turnPlayer(r), row, col)
+	}
+
+	rep := roundTrip(t, r.Replay())
+	assert.Equal(t, len(rep.Events), len(r.Log))
+
+	played, err := Play(rep)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, played.Winner, r.Winner)
+	assert.DeepEqual(t, played.Teams, r.Teams)
+
+	// Starting a new game keeps the finished game's replay.
+	assert.NilError(t, r.NewGame(guesser))
+	assert.DeepEqual(t, roundTrip(t, r.LastReplay), rep)
+}
+
+func TestReplayDuet(t *testing.T) {
+	r := newDuetTestRoom(t)
+
+	for i := 0; !r.Over(); i++ {
+		if i%3 == 2 {
+			r.EndTurn(turnPlayer(r))
+			continue
+		}
+
+		row, col := findTile(t, r.Board, func(tile *Tile) bool { return !tile.Revealed })
+		r.Reveal(turnPlayer(r), row, col)
+	}
+
+	_, err := Play(roundTrip(t, r.Replay()))
+	assert.NilError(t, err)
+}
+
+func TestReplayMismatch(t *testing.T) {
+	r := newTestRoom(t, 2)
+	row, col := ownWord(t, r)
+	r.Reveal(turnPlayer(r), row, col)
+
+	rep := roundTrip(t, r.Replay())
+	rep.Final.WordCounts[r.Turn]++
+	_, err := Play(rep)
+	assert.Assert(t, errors.Is(err, ErrReplayMismatch))
+
+	rep = roundTrip(t, r.Replay())
+	rep.Events[1].Player = "nobody"
+	_, err = Play(rep)
+	assert.Assert(t, errors.Is(err, ErrReplayMismatch))
+
+	rep = roundTrip(t, r.Replay())
+	rep.Board = rep.Board[1:]
+	_, err = Play(rep)
+	assert.Assert(t, errors.Is(err, ErrInvalidReplay))
+}
+
+// TestReplayCorpus plays back recorded games to check that the rules have
+// not changed underneath them.
+func TestReplayCorpus(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "replays", "*.json"))
+	assert.NilError(t, err)
+	assert.Assert(t, len(files) > 0)
+
+	for _, file := range files {
+		file := file
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			b, err := ioutil.ReadFile(file)
+			assert.NilError(t, err)
+
+			var rep Replay
+			assert.NilError(t, json.Unmarshal(b, &rep))
+
+			_, err = Play(&rep)
+			assert.NilError(t, err)
+		})
+	}
+}
+
+func TestReplayUndo(t *testing.T) {
+	r := newTestRoom(t, 3)
+	r.ChangeUndoRule(UndoMajority)
+	team := r.Turn
+
+	row, col := findTile(t, r.Board, func(tile *Tile) bool { return tile.Bomb })
+	r.Reveal(turnPlayer(r), row, col)
+	for _, members := range r.Teams {
+		r.VoteUndo(members[0])
+	}
+	assert.Assert(t, !r.Eliminated[team])
+
+	row, col = ownWord(t, r)
+	r.Reveal(turnPlayer(r), row, col)
+
+	_, err := Play(roundTrip(t, r.Replay()))
+	assert.NilError(t, err)
+}
@@ -1,12 +1,45 @@
 package game
 
 import (
+	"errors"
+	"time"
+	"unicode/utf8"
+
 	"github.com/zikaeroh/codies/internal/words"
 	"github.com/zikaeroh/codies/internal/words/static"
 )
 
 type PlayerID = string
 
+var (
+	ErrNotEnoughWords = errors.New("game: not enough words")
+	ErrInvalidBoard   = errors.New("game: invalid board size")
+	ErrInvalidSeed    = errors.New("game: invalid seed")
+
+	ErrGameOver      = errors.New("game: game is over")
+	ErrNotYourTurn   = errors.New("game: not your turn")
+	ErrNotAllowed    = errors.New("game: not allowed")
+	ErrUnknownPlayer = errors.New("game: unknown player")
+	ErrInvalidTeam   = errors.New("game: invalid team")
+	ErrInvalidTile   = errors.New("game: invalid tile")
+	ErrInvalidClue   = errors.New("game: invalid clue")
+	ErrInvalidPack   = errors.New("game: invalid pack")
+	ErrTooManyPacks  = errors.New("game: too many packs")
+	ErrPackTooLarge  = errors.New("game: pack too large")
+	ErrInvalidMode   = errors.New("game: invalid mode")
+	ErrInvalidOption = errors.New("game: invalid option")
+)
+
+// DefaultMaxPacks is the default limit on the number of packs in a room,
+// including the default packs.
+const DefaultMaxPacks = 10
+
+const (
+	maxPackWords   = 5000
+	maxPackNameLen = 40 // In runes, as are words.
+	maxWordLen     = 40
+)
+
 type WordList struct {
 	Name   string
 	Custom bool
@@ -34,18 +67,35 @@ func defaultWords() []*WordList {
 }
 
 type Room struct {
-	rand Rand
+	rand     Rand
+	now      func() time.Time
+	start    *Replay // The current game as it was started, without events.
+	maxPacks int
 
 	// Configuration for the next new game.
 	Rows, Cols int
 
-	Version   int
-	Board     *Board
-	Turn      Team
-	Winner    *Team
-	Players   map[PlayerID]*Player
-	Teams     [][]PlayerID // To preserve the ordering of teams.
-	WordLists []*WordList
+	Mode       Mode // Changing the mode starts a new game.
+	Version    int
+	Seed       int64 // The seed the current game was dealt from.
+	Board      *Board
+	Turn       Team
+	Winner     *Team
+	Eliminated []bool   // Teams which have revealed a bomb, indexed by team.
+	Duet       *Duet    // Only set in duet games.
+	Clue       *Clue    // The clue for the current turn, if one has been given.
+	Log        []*Event // Events in the current game, in order.
+	LastReplay *Replay  // Replay of the previous game, if any.
+	Undo       *Undo    // The most recent move, if it can be undone.
+	UndoRule   UndoRule
+
+	// Percentage of the current team's guessers who must vote for a tile
+	// before it is revealed. If zero, tiles are revealed on the first click.
+	RevealShare int
+	RevealVotes map[PlayerID]int // Tile index each guesser has voted for.
+	Players     map[PlayerID]*Player
+	Teams       [][]PlayerID // To preserve the ordering of teams.
+	WordLists   []*WordList
 }
 
 func NewRoom(rand Rand) *Room {
@@ -55,19 +105,23 @@ func NewRoom(rand Rand) *Room {
 
 	return &Room{
 		rand:      rand,
+		now:       time.Now,
+		maxPacks:  DefaultMaxPacks,
+		Mode:      ModeClassic,
+		UndoRule:  UndoSpymaster,
 		Rows:      5,
 		Cols:      5,
 		Players:   make(map[PlayerID]*Player),
-		Teams:     make([][]PlayerID, 2), // TODO: support more than 2 teams
+		Teams:     make([][]PlayerID, 2),
 		WordLists: defaultWords(),
 	}
 }
 
 type Player struct {
-	ID        PlayerID
-	Nickname  string
-	Team      Team
-	Spymaster bool
+	ID        PlayerID `json:"id"`
+	Nickname  string   `json:"nickname"`
+	Team      Team     `json:"team"`
+	Spymaster bool     `json:"spymaster"`
 }
 
 func (r *Room) AddPlayer(id PlayerID, nickname string) {
@@ -77,6 +131,7 @@ func (r *Room) AddPlayer(id PlayerID, nickname string) {
 		}
 
 		p.Nickname = nickname
+		r.logEvent(id, &Event{Type: EventChangeNickname})
 		r.Version++
 		return
 	}
@@ -90,6 +145,7 @@ func (r *Room) AddPlayer(id PlayerID, nickname string) {
 
 	r.Players[id] = p
 	r.Teams[team] = append(r.Teams[team], id)
+	r.logEvent(id, &Event{Type: EventJoin, Team: team})
 	r.Version++
 }
 
@@ -116,52 +172,140 @@ func (r *Room) words() (list words.List) {
 	return list
 }
 
-func (r *Room) NewGame() {
-	words := r.words()
+// canStart checks that a game with the given configuration can be started.
+func (r *Room) canStart(mode Mode, rows, cols, numTeams int) error {
+	if !hasLayout(mode, rows, cols, numTeams) {
+		return ErrInvalidBoard
+	}
+
+	if words := r.words(); rows*cols > words.Len() {
+		return ErrNotEnoughWords
+	}
+
+	return nil
+}
+
+// NewGame starts a new game on behalf of a player (or no player, if the ID
+// is empty). If the enabled packs do not contain enough words for the
+// configured board, ErrNotEnoughWords is returned and the current game is left
+// as-is.
+func (r *Room) NewGame(id PlayerID) error {
+	return r.NewSeededGame(id, r.rand.Int63())
+}
+
+// NewSeededGame is like NewGame, but deals the game from the given seed. Games
+// with the same seed, configuration, and word packs have the same board and
+// starting team. The seed must not be negative.
+func (r *Room) NewSeededGame(id PlayerID, seed int64) error {
+	if seed < 0 {
+		return ErrInvalidSeed
+	}
 
-	if r.Rows*r.Cols > words.Len() {
-		panic("not enough words")
+	if err := r.canStart(r.Mode, r.Rows, r.Cols, len(r.Teams)); err != nil {
+		return err
 	}
 
+	if r.start != nil {
+		r.LastReplay = r.Replay()
+	}
+
+	words := r.words()
+	rand := NewSeededRand(seed)
+
+	r.Seed = seed
 	r.Winner = nil
-	r.Turn = Team(r.rand.Intn(len(r.Teams)))
-	r.Board = newBoard(r.Rows, r.Cols, words, r.Turn, len(r.Teams), r.rand)
+	r.Clue = nil
+	r.Undo = nil
+	r.RevealVotes = nil
+	r.Eliminated = make([]bool, len(r.Teams))
+	r.Turn = Team(rand.Intn(len(r.Teams)))
+
+	if r.Mode == ModeDuet {
+		r.Board = newDuetBoard(r.Rows, r.Cols, words, rand)
+		r.Duet = &Duet{TimerTokens: duetTimerTokens}
+	} else {
+		r.Board = newBoard(r.Rows, r.Cols, words, r.Turn, len(r.Teams), rand)
+		r.Duet = nil
+	}
 
 	for _, p := range r.Players {
 		p.Spymaster = false
 	}
 
+	r.start = r.newReplay()
+
+	r.Log = nil
+	r.logEvent(id, &Event{Type: EventNewGame})
+
 	r.Version++
+	return nil
 }
 
-func (r *Room) EndTurn(id PlayerID) {
-	if r.Winner != nil {
-		return
+// Over returns true if the current game has ended.
+func (r *Room) Over() bool {
+	if r.Duet != nil {
+		return r.Duet.Won || r.Duet.Lost
+	}
+	return r.Winner != nil
+}
+
+func (r *Room) EndTurn(id PlayerID) error {
+	if r.Over() {
+		return ErrGameOver
 	}
 
 	p := r.Players[id]
 	if p == nil {
-		return
+		return ErrUnknownPlayer
 	}
 
-	if p.Team != r.Turn || p.Spymaster {
-		return
+	if p.Team != r.Turn {
+		return ErrNotYourTurn
+	}
+
+	if p.Spymaster {
+		return ErrNotAllowed
 	}
 
-	r.ForceEndTurn()
+	r.endTurn(id)
+	return nil
 }
 
 func (r *Room) nextTeam() Team {
-	return r.Turn.next(len(r.Teams))
+	numTeams := len(r.Teams)
+	team := r.Turn.next(numTeams)
+
+	// Skip over eliminated teams; at least one team is always left.
+	for team != r.Turn && r.Eliminated[team] {
+		team = team.next(numTeams)
+	}
+
+	return team
 }
 
 func (r *Room) nextTurn() {
+	r.Clue = nil
+	r.RevealVotes = nil
+
+	if r.Duet != nil {
+		r.duetEndTurn()
+		return
+	}
 	r.Turn = r.nextTeam()
 }
 
+// ForceEndTurn ends the current turn on behalf of the turn timer.
 func (r *Room) ForceEndTurn() {
+	r.endTurn("")
+}
+
+func (r *Room) endTurn(id PlayerID) {
+	e := &Event{Type: EventEndTurn}
+	r.logEvent(id, e)
+	r.saveUndo(e)
 	r.Version++
 	r.nextTurn()
+	r.forgetFinalUndo()
 }
 
 func (r *Room) RemovePlayer(id PlayerID) {
@@ -170,32 +314,86 @@ func (r *Room) RemovePlayer(id PlayerID) {
 		return
 	}
 
+	r.logEvent(id, &Event{Type: EventLeave})
+
 	r.Version++
 	delete(r.Players, id)
+	delete(r.RevealVotes, id)
 
 	r.Teams[p.Team] = removePlayer(r.Teams[p.Team], id)
 }
 
-func (r *Room) Reveal(id PlayerID, row, col int) {
-	if r.Winner != nil {
-		return
+// Reveal reveals a tile on behalf of a guesser on the current team. In rooms
+// where reveals are voted on, this casts the player's vote instead, and the
+// tile is only revealed once enough of their team agree.
+func (r *Room) Reveal(id PlayerID, row, col int) error {
+	p, tile, err := r.revealable(id, row, col)
+	if err != nil {
+		return err
+	}
+
+	var voters []string
+	if r.RevealShare > 0 {
+		var agreed bool
+		index, _ := r.Board.Index(row, col) // Checked by revealable.
+		voters, agreed = r.voteReveal(p, index)
+		if !agreed {
+			return nil
+		}
+	}
+
+	r.reveal(p, row, col, tile, voters)
+	return nil
+}
+
+// revealable returns the player and the tile if the player may reveal it.
+func (r *Room) revealable(id PlayerID, row, col int) (*Player, *Tile, error) {
+	if r.Over() {
+		return nil, nil, ErrGameOver
 	}
 
 	p := r.Players[id]
 	if p == nil {
-		return
+		return nil, nil, ErrUnknownPlayer
 	}
 
-	if p.Spymaster || p.Team != r.Turn {
-		return
+	if p.Team != r.Turn {
+		return nil, nil, ErrNotYourTurn
+	}
+
+	if p.Spymaster {
+		return nil, nil, ErrNotAllowed
 	}
 
 	tile := r.Board.Get(row, col)
-	if tile == nil {
-		return
+	if tile == nil || tile.Revealed {
+		return nil, nil, ErrInvalidTile
+	}
+
+	// In duet, the clue giver's key has already shown marked tiles to be bystanders.
+	if r.Duet != nil && tile.Marked[r.duetKeySide()] {
+		return nil, nil, ErrInvalidTile
 	}
 
-	if tile.Revealed {
+	return p, tile, nil
+}
+
+func (r *Room) reveal(p *Player, row, col int, tile *Tile, voters []string) {
+	e := &Event{
+		Type:   EventReveal,
+		Row:    row,
+		Col:    col,
+		Tile:   copyTile(tile),
+		Voters: voters,
+	}
+	r.logEvent(p.ID, e)
+	r.saveUndo(e)
+	r.RevealVotes = nil
+
+	if r.Duet != nil {
+		r.duetReveal(tile)
+		r.forgetFinalUndo()
+		r.Version++
 		return
 	}
 
@@ -205,59 +403,179 @@ func (r *Room) Reveal(id PlayerID, row, col int) {
 	case tile.Neutral:
 		r.nextTurn()
 	case tile.Bomb:
-		// TODO: Who wins when there's more than one team?
-		// Maybe eliminate the team who clicked?
-		winner := r.nextTeam()
-		r.Winner = &winner
+		r.eliminate(p.Team)
 	default:
 		r.Board.WordCounts[tile.Team]--
-		if r.Board.WordCounts[tile.Team] == 0 {
+		if r.Board.WordCounts[tile.Team] == 0 && !r.Eliminated[tile.Team] {
 			winner := tile.Team
 			r.Winner = &winner
 		} else if tile.Team != p.Team {
 			r.nextTurn()
+		} else {
+			r.useGuess()
 		}
 	}
 
+	r.forgetFinalUndo()
 	r.Version++
 }
 
-func (r *Room) ChangeRole(id PlayerID, spymaster bool) {
-	if r.Winner != nil {
+// eliminate knocks a team out of the game. If only one team remains, it wins;
+// otherwise, play passes to the next remaining team.
+func (r *Room) eliminate(team Team) {
+	r.Eliminated[team] = true
+
+	remaining := make([]Team, 0, len(r.Teams))
+	for t, eliminated := range r.Eliminated {
+		if !eliminated {
+			remaining = append(remaining, Team(t))
+		}
+	}
+
+	if len(remaining) == 1 {
+		winner := remaining[0]
+		r.Winner = &winner
 		return
 	}
 
+	r.nextTurn()
+}
+
+func (r *Room) ChangeRole(id PlayerID, spymaster bool) error {
+	if r.Over() {
+		return ErrGameOver
+	}
+
+	// Duet has no spymasters; each side always sees its own key card.
+	if r.Mode == ModeDuet {
+		return ErrNotAllowed
+	}
+
 	p := r.Players[id]
 	if p == nil {
-		return
+		return ErrUnknownPlayer
 	}
 
 	if p.Spymaster == spymaster {
-		return
+		return nil
 	}
 
+	r.logEvent(id, &Event{Type: EventChangeRole, Spymaster: spymaster})
+
+	delete(r.RevealVotes, id)
 	p.Spymaster = spymaster
 	r.Version++
+	return nil
 }
 
-func (r *Room) ChangeTeam(id PlayerID, team Team) {
+func (r *Room) ChangeTeam(id PlayerID, team Team) error {
 	if team < 0 || int(team) >= len(r.Teams) {
-		return
+		return ErrInvalidTeam
 	}
 
 	p := r.Players[id]
 	if p == nil {
-		return
+		return ErrUnknownPlayer
 	}
 
 	if p.Team == team {
-		return
+		return nil
 	}
 
+	r.logEvent(id, &Event{Type: EventChangeTeam, Team: team})
+
+	delete(r.RevealVotes, id)
 	r.Teams[p.Team] = removePlayer(r.Teams[p.Team], id)
 	r.Teams[team] = append(r.Teams[team], id)
 	p.Team = team
 	r.Version++
+	return nil
+}
+
+// ChangeNumTeams changes the number of teams in the room. Players on teams
+// which no longer exist are moved to the smallest remaining teams. As the
+// board depends on the number of teams, a new game is started.
+func (r *Room) ChangeNumTeams(id PlayerID, numTeams int) error {
+	if numTeams == len(r.Teams) {
+		return nil
+	}
+
+	if err := r.canStart(r.Mode, r.Rows, r.Cols, numTeams); err != nil {
+		return err
+	}
+
+	r.setNumTeams(numTeams)
+	return r.NewGame(id)
+}
+
+// ChangeMode changes the game mode and starts a new game. Duet games are
+// played by two sides, so the number of teams is changed to match.
+func (r *Room) ChangeMode(id PlayerID, mode Mode) error {
+	if mode == r.Mode {
+		return nil
+	}
+
+	numTeams := len(r.Teams)
+
+	switch mode {
+	case ModeClassic:
+	case ModeDuet:
+		numTeams = duetSides
+	default:
+		return ErrInvalidMode
+	}
+
+	if err := r.canStart(mode, r.Rows, r.Cols, numTeams); err != nil {
+		return err
+	}
+
+	r.Mode = mode
+	r.setNumTeams(numTeams)
+	return r.NewGame(id)
+}
+
+// setNumTeams resizes the teams, moving players on removed teams to the
+// smallest remaining teams. A new game must be started after calling this.
+func (r *Room) setNumTeams(numTeams int) {
+	var moved []PlayerID
+	if numTeams < len(r.Teams) {
+		for _, members := range r.Teams[numTeams:] {
+			moved = append(moved, members...)
+		}
+		r.Teams = r.Teams[:numTeams]
+	} else {
+		for len(r.Teams) < numTeams {
+			r.Teams = append(r.Teams, nil)
+		}
+	}
+
+	for _, id := range moved {
+		team := r.smallestTeam()
+		r.Teams[team] = append(r.Teams[team], id)
+		r.Players[id].Team = team
+	}
+}
+
+// ChangeBoardSize sets the board size for the next game. Only boards which are
+// square or nearly square and have a layout for the current number of teams
+// are allowed.
+func (r *Room) ChangeBoardSize(rows, cols int) error {
+	if rows == r.Rows && cols == r.Cols {
+		return nil
+	}
+
+	if diff := rows - cols; diff < -1 || diff > 1 {
+		return ErrInvalidBoard
+	}
+
+	if !hasLayout(r.Mode, rows, cols, len(r.Teams)) {
+		return ErrInvalidBoard
+	}
+
+	r.Rows = rows
+	r.Cols = cols
+	r.Version++
+	return nil
 }
 
 func removePlayer(team []PlayerID, remove PlayerID) []PlayerID {
@@ -270,7 +588,7 @@ func removePlayer(team []PlayerID, remove PlayerID) []PlayerID {
 	return newTeam
 }
 
-func (r *Room) RandomizeTeams() {
+func (r *Room) RandomizeTeams(id PlayerID) {
 	players := make([]PlayerID, 0, len(r.Players))
 	for id := range r.Players {
 		players = append(players, id)
@@ -295,25 +613,33 @@ func (r *Room) RandomizeTeams() {
 		newTeams[i], newTeams[j] = newTeams[j], newTeams[i]
 	})
 
-	for team, players := range newTeams {
+	r.setTeams(id, newTeams)
+}
+
+// setTeams replaces the teams, which must contain every player exactly once.
+func (r *Room) setTeams(id PlayerID, teams [][]PlayerID) {
+	for team, players := range teams {
 		for _, id := range players {
 			r.Players[id].Team = Team(team)
 		}
 	}
 
-	r.Teams = newTeams
+	r.logEvent(id, &Event{Type: EventRandomizeTeams, Teams: copyTeams(teams)})
+
+	r.RevealVotes = nil
+	r.Teams = teams
 	r.Version++
 }
 
-func (r *Room) ChangePack(num int, enable bool) {
+func (r *Room) ChangePack(num int, enable bool) error {
 	if num < 0 || num >= len(r.WordLists) {
-		return
+		return ErrInvalidPack
 	}
 
 	pack := r.WordLists[num]
 
 	if pack.Enabled == enable {
-		return
+		return nil
 	}
 
 	if !enable {
@@ -325,17 +651,34 @@ func (r *Room) ChangePack(num int, enable bool) {
 		}
 
 		if total < 2 {
-			return
+			return ErrNotAllowed
 		}
 	}
 
 	pack.Enabled = enable
 	r.Version++
+	return nil
 }
 
-func (r *Room) AddPack(name string, wds []string) {
-	if len(r.WordLists) >= 10 {
-		return
+// SetMaxPacks limits the number of packs which may be added to the room. Packs
+// already in the room are kept.
+func (r *Room) SetMaxPacks(n int) {
+	r.maxPacks = n
+}
+
+func (r *Room) AddPack(name string, wds []string) error {
+	if len(r.WordLists) >= r.maxPacks {
+		return ErrTooManyPacks
+	}
+
+	if len(wds) > maxPackWords || utf8.RuneCountInString(name) > maxPackNameLen {
+		return ErrPackTooLarge
+	}
+
+	for _, w := range wds {
+		if utf8.RuneCountInString(w) > maxWordLen {
+			return ErrPackTooLarge
+		}
 	}
 
 	list := &WordList{
@@ -345,15 +688,16 @@ func (r *Room) AddPack(name string, wds []string) {
 	}
 	r.WordLists = append(r.WordLists, list)
 	r.Version++
+	return nil
 }
 
-func (r *Room) RemovePack(num int) {
+func (r *Room) RemovePack(num int) error {
 	if num < 0 || num >= len(r.WordLists) {
-		return
+		return ErrInvalidPack
 	}
 
 	if pack := r.WordLists[num]; !pack.Custom || pack.Enabled {
-		return
+		return ErrNotAllowed
 	}
 
 	// https://github.com/golang/go/wiki/SliceTricks
@@ -364,4 +708,5 @@ func (r *Room) RemovePack(num int) {
 	r.WordLists = lists
 
 	r.Version++
+	return nil
 }
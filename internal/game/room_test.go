@@ -0,0 +1,236 @@
+package game
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func newTestRoom(t *testing.T, numTeams int) *Room {
+	t.Helper()
+
+	r := NewRoom(rand.New(rand.NewSource(1))) //nolint:gosec
+	r.ChangeNumTeams("", numTeams)
+
+	for team := 0; team < numTeams; team++ {
+		id := PlayerID(rune('a' + team))
+		r.AddPlayer(id, id)
+		assert.Equal(t, r.Players[id].Team, Team(team))
+	}
+
+	assert.NilError(t, r.NewGame(""))
+	return r
+}
+
+func findTile(t *testing.T, b *Board, fn func(*Tile) bool) (row, col int) {
+	t.Helper()
+
+	found := false
+	b.Tiles(func(r, c int, tile *Tile) bool {
+		if fn(tile) {
+			row, col, found = r, c, true
+			return false
+		}
+		return true
+	})
+
+	if !found {
+		t.Fatal("tile not found")
+	}
+	return row, col
+}
+
+func turnPlayer(r *Room) PlayerID {
+	return r.Teams[r.Turn][0]
+}
+
+func TestBombTwoTeams(t *testing.T) {
+	r := newTestRoom(t, 2)
+
+	loser := r.Turn
+	row, col := findTile(t, r.Board, func(tile *Tile) bool { return tile.Bomb })
+	r.Reveal(turnPlayer(r), row, col)
+
+	assert.Assert(t, r.Eliminated[loser])
+	assert.Assert(t, r.Winner != nil)
+	assert.Equal(t, *r.Winner, loser.next(2))
+}
+
+func TestBombEliminatesTeam(t *testing.T) {
+	r := newTestRoom(t, 3)
+
+	eliminated := r.Turn
+	row, col := findTile(t, r.Board, func(tile *Tile) bool { return tile.Bomb })
+	r.Reveal(turnPlayer(r), row, col)
+
+	assert.Assert(t, r.Eliminated[eliminated])
+	assert.Assert(t, r.Winner == nil)
+	assert.Assert(t, r.Turn != eliminated)
+
+	for i := 0; i < 6; i++ {
+		r.ForceEndTurn()
+		assert.Assert(t, r.Turn != eliminated)
+	}
+}
+
+func TestEliminatedTeamCannotWin(t *testing.T) {
+	r := newTestRoom(t, 3)
+
+	eliminated := r.Turn
+	row, col := findTile(t, r.Board, func(tile *Tile) bool { return tile.Bomb })
+	r.Reveal(turnPlayer(r), row, col)
+
+	// Reveal all of the eliminated team's words on behalf of the other teams.
+	for r.Board.WordCounts[eliminated] > 0 {
+		row, col := findTile(t, r.Board, func(tile *Tile) bool {
+			return !tile.Revealed && !tile.Neutral && !tile.Bomb && tile.Team == eliminated
+		})
+		r.Reveal(turnPlayer(r), row, col)
+	}
+
+	assert.Assert(t, r.Winner == nil)
+}
+
+func TestChangeNumTeams(t *testing.T) {
+	r := newTestRoom(t, 4)
+
+	r.ChangeNumTeams("", 2)
+	assert.Equal(t, len(r.Teams), 2)
+	assert.Equal(t, len(r.Board.WordCounts), 2)
+
+	total := 0
+	for team, members := range r.Teams {
+		total += len(members)
+		for _, id := range members {
+			assert.Equal(t, r.Players[id].Team, Team(team))
+		}
+	}
+	assert.Equal(t, total, 4)
+
+	before := r.Version
+	r.ChangeNumTeams("", 5)
+	assert.Equal(t, len(r.Teams), 2)
+	assert.Equal(t, r.Version, before)
+}
+
+func TestNewGameNotEnoughWords(t *testing.T) {
+	r := newTestRoom(t, 2)
+
+	words := make([]string, 25)
+	for i := range words {
+		words[i] = string(rune('A' + i))
+	}
+
+	r.AddPack("small", words)
+	r.ChangePack(3, true)
+	r.ChangePack(0, false)
+	r.ChangeBoardSize(6, 6)
+	assert.Equal(t, r.Rows, 6)
+
+	before := r.Version
+	assert.Equal(t, r.NewGame(""), ErrNotEnoughWords)
+	assert.Equal(t, r.Version, before)
+	assert.Equal(t, r.Board.Rows, 5)
+}
+
+func TestChangeBoardSize(t *testing.T) {
+	r := newTestRoom(t, 2)
+
+	assert.Equal(t, r.ChangeBoardSize(2, 8), ErrInvalidBoard)
+	assert.Equal(t, r.Rows, 5)
+
+	assert.Equal(t, r.ChangeBoardSize(4, 5), ErrInvalidBoard)
+	assert.Equal(t, r.Rows, 5)
+
+	assert.NilError(t, r.ChangeBoardSize(7, 7))
+	assert.Equal(t, r.Rows, 7)
+	assert.Equal(t, r.Cols, 7)
+
+	assert.NilError(t, r.NewGame(""))
+	assert.Equal(t, r.Board.Rows, 7)
+	assert.Equal(t, r.Board.Cols, 7)
+
+	// Every tile of a non-square board is reachable.
+	r.ChangeBoardSize(6, 5)
+	assert.NilError(t, r.NewGame(""))
+
+	seen := make(map[*Tile]bool)
+	for row := 0; row < r.Board.Rows; row++ {
+		for col := 0; col < r.Board.Cols; col++ {
+			tile := r.Board.Get(row, col)
+			assert.Assert(t, tile != nil && !seen[tile], "row=%d col=%d", row, col)
+			seen[tile] = true
+		}
+	}
+}
+
+func TestAddPackLimits(t *testing.T) {
+	r := newTestRoom(t, 2)
+	numPacks := len(r.WordLists)
+
+	words := make([]string, 25)
+	for i := range words {
+		words[i] = string(rune('A' + i))
+	}
+
+	assert.Equal(t, r.AddPack(strings.Repeat("n", maxPackNameLen+1), words), ErrPackTooLarge)
+	assert.Equal(t, r.AddPack("huge", make([]string, maxPackWords+1)), ErrPackTooLarge)
+
+	words[0] = strings.Repeat("w", maxWordLen+1)
+	assert.Equal(t, r.AddPack("long", words), ErrPackTooLarge)
+	assert.Equal(t, len(r.WordLists), numPacks)
+
+	words[0] = "A"
+	for len(r.WordLists) < DefaultMaxPacks {
+		assert.NilError(t, r.AddPack("pack", words))
+	}
+	assert.Equal(t, r.AddPack("pack", words), ErrTooManyPacks)
+}
+
+func TestNewSeededGame(t *testing.T) {
+	deal := func(seed int64) *Room {
+		r := newTestRoom(t, 3)
+		assert.NilError(t, r.NewSeededGame("", seed))
+		assert.Equal(t, r.Seed, seed)
+		return r
+	}
+
+	a, b := deal(42), deal(42)
+	assert.Equal(t, a.Turn, b.Turn)
+	assert.DeepEqual(t, a.Board.tiles, b.Board.tiles)
+
+	// Seeds use all 63 bits.
+	big := deal(1 << 62)
+	assert.Equal(t, big.Seed, int64(1<<62))
+
+	c := deal(43)
+	assert.Assert(t, a.Board.Get(0, 0).Word != c.Board.Get(0, 0).Word || a.Board.Get(0, 1).Word != c.Board.Get(0, 1).Word)
+
+	assert.Equal(t, a.NewSeededGame("", -1), ErrInvalidSeed)
+	assert.Equal(t, a.Seed, int64(42))
+}
+
+func TestMoveErrors(t *testing.T) {
+	r := newTestRoom(t, 2)
+	guesser := turnPlayer(r)
+	other := r.Teams[r.Turn.next(2)][0]
+	before := r.Version
+
+	assert.Equal(t, r.Reveal(other, 0, 0), ErrNotYourTurn)
+	assert.Equal(t, r.EndTurn(other), ErrNotYourTurn)
+	assert.Equal(t, r.Reveal("nobody", 0, 0), ErrUnknownPlayer)
+	assert.Equal(t, r.Reveal(guesser, 5, 0), ErrInvalidTile)
+	assert.Equal(t, r.GiveClue(guesser, "ocean", 1, false), ErrNotAllowed)
+	assert.Equal(t, r.ChangeTeam(guesser, 2), ErrInvalidTeam)
+	assert.Equal(t, r.ChangePack(0, false), ErrNotAllowed)
+	assert.Equal(t, r.RemovePack(0), ErrNotAllowed)
+	assert.Equal(t, r.ChangeMode("", "chess"), ErrInvalidMode)
+	assert.Equal(t, r.ChangeRevealShare(101), ErrInvalidOption)
+	assert.Equal(t, r.VoteUndo(guesser), ErrNotAllowed)
+	assert.Equal(t, r.Version, before)
+
+	assert.NilError(t, r.ChangeRole(guesser, true))
+	assert.Equal(t, r.Reveal(guesser, 0, 0), ErrNotAllowed)
+}
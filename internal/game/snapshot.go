@@ -0,0 +1,303 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/zikaeroh/codies/internal/words"
+)
+
+var errBadSnapshot = errors.New("game: invalid room snapshot")
+
+// roomJSON is the serialized form of a Room. It contains everything needed
+// to restore the room, including its current game.
+type roomJSON struct {
+	Rows        int                  `json:"rows"`
+	Cols        int                  `json:"cols"`
+	Mode        Mode                 `json:"mode"`
+	Version     int                  `json:"version"`
+	Seed        int64                `json:"seed"`
+	Board       *Board               `json:"board"`
+	Turn        Team                 `json:"turn"`
+	Winner      *Team                `json:"winner"`
+	Eliminated  []bool               `json:"eliminated"`
+	Duet        *Duet                `json:"duet"`
+	Clue        *Clue                `json:"clue"`
+	Log         []*Event             `json:"log"`
+	Start       *Replay              `json:"start"`
+	LastReplay  *Replay              `json:"lastReplay"`
+	Undo        *Undo                `json:"undo"`
+	UndoRule    UndoRule             `json:"undoRule"`
+	RevealShare int                  `json:"revealShare"`
+	RevealVotes map[PlayerID]int     `json:"revealVotes"`
+	Players     map[PlayerID]*Player `json:"players"`
+	Teams       [][]PlayerID         `json:"teams"`
+	WordLists   []*WordList          `json:"wordLists"`
+}
+
+// MarshalJSON serializes the room, so that it can be persisted and later
+// restored with UnmarshalJSON.
+func (r *Room) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&roomJSON{
+		Rows:        r.Rows,
+		Cols:        r.Cols,
+		Mode:        r.Mode,
+		Version:     r.Version,
+		Seed:        r.Seed,
+		Board:       r.Board,
+		Turn:        r.Turn,
+		Winner:      r.Winner,
+		Eliminated:  r.Eliminated,
+		Duet:        r.Duet,
+		Clue:        r.Clue,
+		Log:         r.Log,
+		Start:       r.start,
+		LastReplay:  r.LastReplay,
+		Undo:        r.Undo,
+		UndoRule:    r.UndoRule,
+		RevealShare: r.RevealShare,
+		RevealVotes: r.RevealVotes,
+		Players:     r.Players,
+		Teams:       r.Teams,
+		WordLists:   r.WordLists,
+	})
+}
+
+// UnmarshalJSON restores a room serialized by MarshalJSON. The room should
+// be created with NewRoom first, so that it has a source of randomness.
+func (r *Room) UnmarshalJSON(data []byte) error {
+	var v roomJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	if v.Board == nil || len(v.Teams) == 0 || len(v.WordLists) == 0 {
+		return errBadSnapshot
+	}
+
+	numTeams := len(v.Teams)
+	if len(v.Eliminated) != numTeams || v.Turn < 0 || int(v.Turn) >= numTeams {
+		return errBadSnapshot
+	}
+
+	if v.Players == nil {
+		v.Players = make(map[PlayerID]*Player)
+	}
+
+	for _, p := range v.Players {
+		if p == nil || p.Team < 0 || int(p.Team) >= numTeams {
+			return errBadSnapshot
+		}
+	}
+
+	for team, members := range v.Teams {
+		for _, id := range members {
+			if p := v.Players[id]; p == nil || p.Team != Team(team) {
+				return errBadSnapshot
+			}
+		}
+	}
+
+	duet := v.Duet != nil
+	if len(v.Board.WordCounts) != numTeams || !validTiles(v.Board.tiles, len(v.Board.tiles), numTeams, duet) {
+		return errBadSnapshot
+	}
+
+	if u := v.Undo; u != nil {
+		if u.turn < 0 || int(u.turn) >= numTeams || len(u.wordCounts) != numTeams || len(u.eliminated) != numTeams ||
+			(u.duet != nil) != duet || !validTiles(u.tiles, len(v.Board.tiles), numTeams, duet) {
+			return errBadSnapshot
+		}
+	}
+
+	r.Rows = v.Rows
+	r.Cols = v.Cols
+	r.Mode = v.Mode
+	r.Version = v.Version
+	r.Seed = v.Seed
+	r.Board = v.Board
+	r.Turn = v.Turn
+	r.Winner = v.Winner
+	r.Eliminated = v.Eliminated
+	r.Duet = v.Duet
+	r.Clue = v.Clue
+	r.Log = v.Log
+	r.start = v.Start
+	r.LastReplay = v.LastReplay
+	r.Undo = v.Undo
+	r.UndoRule = v.UndoRule
+	r.RevealShare = v.RevealShare
+	r.RevealVotes = v.RevealVotes
+	r.Players = v.Players
+	r.Teams = v.Teams
+	r.WordLists = v.WordLists
+	return nil
+}
+
+// validTiles checks that there are n tiles, each belonging to one of the teams
+// and with a key card role and mark for each side only in duet games.
+func validTiles(tiles []*Tile, n int, numTeams int, duet bool) bool {
+	if len(tiles) != n {
+		return false
+	}
+
+	for _, tile := range tiles {
+		if tile == nil || tile.Team < 0 || int(tile.Team) >= numTeams {
+			return false
+		}
+
+		if duet && (len(tile.Duet) != duetSides || len(tile.Marked) != duetSides) {
+			return false
+		}
+
+		if !duet && (tile.Duet != nil || tile.Marked != nil) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type boardJSON struct {
+	Rows       int     `json:"rows"`
+	Cols       int     `json:"cols"`
+	WordCounts []int   `json:"wordCounts"`
+	Tiles      []*Tile `json:"tiles"` // Row-major.
+}
+
+func (b *Board) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&boardJSON{
+		Rows:       b.Rows,
+		Cols:       b.Cols,
+		WordCounts: b.WordCounts,
+		Tiles:      b.tiles,
+	})
+}
+
+func (b *Board) UnmarshalJSON(data []byte) error {
+	var v boardJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	if v.Rows <= 0 || v.Cols <= 0 || len(v.Tiles) != v.Rows*v.Cols {
+		return errBadSnapshot
+	}
+
+	for _, tile := range v.Tiles {
+		if tile == nil {
+			return errBadSnapshot
+		}
+	}
+
+	b.Rows = v.Rows
+	b.Cols = v.Cols
+	b.WordCounts = v.WordCounts
+	b.tiles = v.Tiles
+	return nil
+}
+
+type undoJSON struct {
+	Event      *Event     `json:"event"`
+	Votes      []PlayerID `json:"votes"`
+	Turn       Team       `json:"turn"`
+	Winner     *Team      `json:"winner"`
+	Eliminated []bool     `json:"eliminated"`
+	Duet       *Duet      `json:"duet"`
+	Clue       *Clue      `json:"clue"`
+	WordCounts []int      `json:"wordCounts"`
+	Tiles      []*Tile    `json:"tiles"`
+}
+
+func (u *Undo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&undoJSON{
+		Event:      u.Event,
+		Votes:      u.Votes,
+		Turn:       u.turn,
+		Winner:     u.winner,
+		Eliminated: u.eliminated,
+		Duet:       u.duet,
+		Clue:       u.clue,
+		WordCounts: u.wordCounts,
+		Tiles:      u.tiles,
+	})
+}
+
+func (u *Undo) UnmarshalJSON(data []byte) error {
+	var v undoJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	if v.Event == nil {
+		return errBadSnapshot
+	}
+
+	for _, tile := range v.Tiles {
+		if tile == nil {
+			return errBadSnapshot
+		}
+	}
+
+	u.Event = v.Event
+	u.Votes = v.Votes
+	u.turn = v.Turn
+	u.winner = v.Winner
+	u.eliminated = v.Eliminated
+	u.duet = v.Duet
+	u.clue = v.Clue
+	u.wordCounts = v.WordCounts
+	u.tiles = v.Tiles
+	return nil
+}
+
+// wordListJSON is the serialized form of a WordList. Only custom lists have
+// their words stored; built-in lists are found by name.
+type wordListJSON struct {
+	Name    string   `json:"name"`
+	Custom  bool     `json:"custom"`
+	Enabled bool     `json:"enabled"`
+	Words   []string `json:"words,omitempty"`
+}
+
+func (w *WordList) MarshalJSON() ([]byte, error) {
+	v := &wordListJSON{
+		Name:    w.Name,
+		Custom:  w.Custom,
+		Enabled: w.Enabled,
+	}
+
+	if w.Custom {
+		v.Words = make([]string, w.List.Len())
+		for i := range v.Words {
+			v.Words[i] = w.List.Get(i)
+		}
+	}
+
+	return json.Marshal(v)
+}
+
+func (w *WordList) UnmarshalJSON(data []byte) error {
+	var v wordListJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	w.Name = v.Name
+	w.Custom = v.Custom
+	w.Enabled = v.Enabled
+
+	if v.Custom {
+		w.List = words.NewList(v.Words)
+		return nil
+	}
+
+	for _, def := range defaultWords() {
+		if def.Name == v.Name {
+			w.List = def.List
+			return nil
+		}
+	}
+
+	return errBadSnapshot
+}
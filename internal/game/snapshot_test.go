@@ -0,0 +1,133 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func restoreRoom(t *testing.T, r *Room) *Room {
+	t.Helper()
+
+	b, err := json.Marshal(r)
+	assert.NilError(t, err)
+
+	restored := NewRoom(nil)
+	assert.NilError(t, json.Unmarshal(b, restored))
+
+	// The restored room must serialize identically.
+	b2, err := json.Marshal(restored)
+	assert.NilError(t, err)
+	assert.Equal(t, string(b2), string(b))
+
+	return restored
+}
+
+func TestSnapshot(t *testing.T) {
+	r, spymaster, guesser := newClueTestRoom(t)
+
+	words := make([]string, 30)
+	for i := range words {
+		words[i] = string(rune('a'+i%26)) + string(rune('a'+i/26))
+	}
+	r.AddPack("custom", words)
+	r.ChangePack(len(r.WordLists)-1, true)
+	r.ChangeRevealShare(100)
+
+	assert.NilError(t, r.NewGame(guesser))
+	r.ChangeRole(spymaster, true)
+	r.GiveClue(spymaster, "ocean", 1, false)
+	r.EndTurn(turnPlayer(r))
+
+	s := restoreRoom(t, r)
+	assert.DeepEqual(t, s.Board.tiles, r.Board.tiles)
+	assert.DeepEqual(t, s.Players, r.Players)
+	assert.Equal(t, s.WordLists[len(s.WordLists)-1].List.Get(0), "AA")
+	assert.Equal(t, s.WordLists[0].List.Len(), r.WordLists[0].List.Len())
+	assert.Equal(t, s.RevealShare, 100)
+	assert.Assert(t, s.Undo != nil)
+
+	// The restored game can be undone, played on, and replayed.
+	s.ChangeUndoRule(UndoMajority)
+	for _, id := range []PlayerID{spymaster, guesser, "a", "b"} {
+		s.VoteUndo(id)
+	}
+	assert.Equal(t, s.Turn, r.Turn.next(2))
+
+	_, err := Play(roundTrip(t, s.Replay()))
+	assert.NilError(t, err)
+}
+
+func TestSnapshotDuet(t *testing.T) {
+	r := newDuetTestRoom(t)
+	key := r.duetKeySide()
+	row, col := findTile(t, r.Board, func(tile *Tile) bool { return tile.Duet[key] == DuetBystander })
+	r.Reveal(turnPlayer(r), row, col)
+
+	s := restoreRoom(t, r)
+	assert.DeepEqual(t, s.Duet, r.Duet)
+	assert.Assert(t, s.Board.Get(row, col).Marked[key])
+}
+
+func TestSnapshotInvalid(t *testing.T) {
+	r := NewRoom(nil)
+	assert.Assert(t, json.Unmarshal([]byte(`{"board":{"rows":5,"cols":5,"tiles":[]}}`), r) != nil)
+	assert.Assert(t, json.Unmarshal([]byte(`{}`), r) != nil)
+
+	classic := newTestRoom(t, 2)
+	classic.EndTurn(turnPlayer(classic))
+
+	duet := newDuetTestRoom(t)
+	duet.EndTurn(turnPlayer(duet))
+
+	type object = map[string]interface{}
+
+	tests := []struct {
+		name    string
+		room    *Room
+		corrupt func(v object)
+	}{
+		{"eliminated", classic, func(v object) { v["eliminated"] = []bool{false} }},
+		{"player team", classic, func(v object) {
+			for _, p := range v["players"].(object) {
+				p.(object)["team"] = 2
+			}
+		}},
+		{"unknown team member", classic, func(v object) {
+			teams := v["teams"].([]interface{})
+			teams[0] = append(teams[0].([]interface{}), "nobody")
+		}},
+		{"word counts", classic, func(v object) { v["board"].(object)["wordCounts"] = []int{1, 2, 3} }},
+		{"tile team", classic, func(v object) { v["board"].(object)["tiles"].([]interface{})[0].(object)["team"] = 2 }},
+		{"duet roles", duet, func(v object) {
+			v["board"].(object)["tiles"].([]interface{})[0].(object)["duet"] = []DuetRole{DuetAgent}
+		}},
+		{"duet marks", duet, func(v object) { v["board"].(object)["tiles"].([]interface{})[0].(object)["marked"] = nil }},
+		{"undo word counts", classic, func(v object) { v["undo"].(object)["wordCounts"] = []int{1} }},
+		{"undo eliminated", classic, func(v object) { v["undo"].(object)["eliminated"] = []bool{} }},
+		{"undo tiles", classic, func(v object) {
+			undo := v["undo"].(object)
+			undo["tiles"] = undo["tiles"].([]interface{})[1:]
+		}},
+		{"undo duet tiles", duet, func(v object) {
+			v["undo"].(object)["tiles"].([]interface{})[0].(object)["duet"] = nil
+		}},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			b, err := json.Marshal(test.room)
+			assert.NilError(t, err)
+
+			var v object
+			assert.NilError(t, json.Unmarshal(b, &v))
+			test.corrupt(v)
+
+			bad, err := json.Marshal(v)
+			assert.NilError(t, err)
+			assert.Equal(t, json.Unmarshal(bad, NewRoom(nil)), errBadSnapshot)
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package game
+
+// UndoRule decides when enough players have agreed to an undo.
+type UndoRule string
+
+const (
+	// UndoSpymaster undoes a move once a spymaster of another team agrees. In
+	// duet games, which have no spymasters, any player on the other side may
+	// agree.
+	UndoSpymaster UndoRule = "spymaster"
+
+	// UndoMajority undoes a move once a majority of the players in the room
+	// agree.
+	UndoMajority UndoRule = "majority"
+)
+
+// Undo is a move which can be undone, along with the state the game was in
+// before it.
+type Undo struct {
+	Event *Event     // The reveal or turn end to undo.
+	Votes []PlayerID // Players who have agreed to the undo, in order.
+
+	turn       Team
+	winner     *Team
+	eliminated []bool
+	duet       *Duet
+	clue       *Clue
+	wordCounts []int
+	tiles      []*Tile
+}
+
+// saveUndo records the state of the game before the move described by the
+// event, replacing any previous undo. Only the most recent move can be undone.
+func (r *Room) saveUndo(e *Event) {
+	u := &Undo{
+		Event:      e,
+		turn:       r.Turn,
+		eliminated: append([]bool(nil), r.Eliminated...),
+		duet:       copyDuet(r.Duet),
+		wordCounts: append([]int(nil), r.Board.WordCounts...),
+		tiles:      copyTiles(r.Board.tiles),
+	}
+
+	if r.Winner != nil {
+		winner := *r.Winner
+		u.winner = &winner
+	}
+
+	if r.Clue != nil {
+		clue := *r.Clue
+		u.clue = &clue
+	}
+
+	r.Undo = u
+}
+
+// forgetFinalUndo drops the undo of a move which ended the game. The end of the
+// game shows every tile, so the move can't be taken back.
+func (r *Room) forgetFinalUndo() {
+	if r.Over() {
+		r.Undo = nil
+	}
+}
+
+// VoteUndo agrees to undo the most recent reveal or turn end on behalf of a
+// player. Once the room's undo rule is met, the move is undone, restoring the
+// board and turn to what they were before it.
+func (r *Room) VoteUndo(id PlayerID) error {
+	u := r.Undo
+	if u == nil {
+		return ErrNotAllowed
+	}
+
+	if r.Players[id] == nil {
+		return ErrUnknownPlayer
+	}
+
+	for _, v := range u.Votes {
+		if v == id {
+			return nil
+		}
+	}
+
+	u.Votes = append(u.Votes, id)
+	r.Version++
+
+	if !r.undoAgreed() {
+		return nil
+	}
+
+	voters := make([]string, 0, len(u.Votes))
+	for _, v := range u.Votes {
+		if p := r.Players[v]; p != nil {
+			voters = append(voters, p.Nickname)
+		}
+	}
+
+	r.undo(id, voters)
+	return nil
+}
+
+func (r *Room) undoAgreed() bool {
+	u := r.Undo
+	votes := 0
+
+	for _, v := range u.Votes {
+		p := r.Players[v]
+		if p == nil {
+			continue
+		}
+		votes++
+
+		if r.UndoRule == UndoSpymaster && p.Team != u.Event.Turn && (p.Spymaster || r.Duet != nil) {
+			return true
+		}
+	}
+
+	return r.UndoRule == UndoMajority && 2*votes > len(r.Players)
+}
+
+// undo undoes the most recent move, logging that it was undone with the
+// agreement of the voters.
+func (r *Room) undo(id PlayerID, voters []string) {
+	u := r.Undo
+
+	r.logEvent(id, &Event{Type: EventUndo, Undone: u.Event, Voters: voters})
+
+	r.Turn = u.turn
+	r.Winner = u.winner
+	r.Eliminated = u.eliminated
+	r.Duet = u.duet
+	r.Clue = u.clue
+	r.Board.WordCounts = u.wordCounts
+	r.Board.tiles = u.tiles
+	r.Undo = nil
+	r.RevealVotes = nil
+
+	r.Version++
+}
+
+// ChangeUndoRule changes the rule used to decide when a move is undone.
+func (r *Room) ChangeUndoRule(rule UndoRule) error {
+	if rule == r.UndoRule {
+		return nil
+	}
+
+	switch rule {
+	case UndoSpymaster, UndoMajority:
+	default:
+		return ErrInvalidOption
+	}
+
+	r.UndoRule = rule
+	r.Version++
+	return nil
+}
@@ -0,0 +1,89 @@
+package game
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestUndoBomb(t *testing.T) {
+	r := newTestRoom(t, 3)
+	team := r.Turn
+	guesser := turnPlayer(r)
+
+	other := PlayerID("other")
+	r.AddPlayer(other, other)
+	r.ChangeTeam(other, team.next(3))
+	r.ChangeRole(other, true)
+
+	wordCounts := append([]int(nil), r.Board.WordCounts...)
+
+	row, col := findTile(t, r.Board, func(tile *Tile) bool { return tile.Bomb })
+	r.Reveal(guesser, row, col)
+	assert.Assert(t, r.Eliminated[team])
+	assert.Assert(t, !r.Over())
+
+	// The team which made the move can't undo it alone.
+	r.VoteUndo(guesser)
+	assert.Assert(t, r.Eliminated[team])
+	assert.DeepEqual(t, r.Undo.Votes, []PlayerID{guesser})
+
+	r.VoteUndo(other)
+	assert.Assert(t, r.Undo == nil)
+	assert.Assert(t, !r.Eliminated[team])
+	assert.Assert(t, !r.Board.Get(row, col).Revealed)
+	assert.Equal(t, r.Turn, team)
+	assert.DeepEqual(t, r.Board.WordCounts, wordCounts)
+
+	e := r.Log[len(r.Log)-1]
+	assert.Equal(t, e.Type, EventUndo)
+	assert.Equal(t, e.Player, other)
+	assert.Equal(t, e.Undone.Type, EventReveal)
+	assert.DeepEqual(t, e.Voters, []string{guesser, other})
+
+	// Only the most recent move can be undone.
+	r.VoteUndo(other)
+	assert.Equal(t, r.Log[len(r.Log)-1], e)
+}
+
+func TestUndoGameOver(t *testing.T) {
+	r := newTestRoom(t, 2)
+	team := r.Turn
+
+	other := r.Teams[team.next(2)][0]
+	r.ChangeRole(other, true)
+
+	row, col := findTile(t, r.Board, func(tile *Tile) bool { return tile.Bomb })
+	r.Reveal(turnPlayer(r), row, col)
+	assert.Assert(t, r.Over())
+
+	// The whole board is shown once the game is over, so the move is final.
+	assert.Assert(t, r.Undo == nil)
+	assert.Equal(t, r.VoteUndo(other), ErrNotAllowed)
+	assert.Assert(t, r.Over())
+}
+
+func TestUndoMajority(t *testing.T) {
+	r := newTestRoom(t, 2)
+	r.ChangeUndoRule(UndoMajority)
+	team := r.Turn
+
+	r.EndTurn(turnPlayer(r))
+	assert.Equal(t, r.Turn, team.next(2))
+
+	r.VoteUndo(r.Teams[0][0])
+	assert.Assert(t, r.Undo != nil)
+
+	r.VoteUndo(r.Teams[1][0])
+	assert.Assert(t, r.Undo == nil)
+	assert.Equal(t, r.Turn, team)
+}
+
+func TestUndoNewGame(t *testing.T) {
+	r := newTestRoom(t, 2)
+	r.EndTurn(turnPlayer(r))
+	assert.Assert(t, r.Undo != nil)
+
+	assert.NilError(t, r.NewGame(""))
+	assert.Assert(t, r.Undo == nil)
+}
@@ -0,0 +1,76 @@
+package game
+
+// voteReveal casts a guesser's vote for the tile at the index, replacing their
+// previous vote. Voting for the same tile again withdraws the vote. If enough
+// of the current team's guessers now agree, it returns true and the nicknames
+// of the players who voted for the tile.
+func (r *Room) voteReveal(p *Player, index int) (voters []string, agreed bool) {
+	if prev, ok := r.RevealVotes[p.ID]; ok && prev == index {
+		delete(r.RevealVotes, p.ID)
+		r.Version++
+		return nil, false
+	}
+
+	if r.RevealVotes == nil {
+		r.RevealVotes = make(map[PlayerID]int)
+	}
+	r.RevealVotes[p.ID] = index
+	r.Version++
+
+	guessers := 0
+	for _, id := range r.Teams[r.Turn] {
+		q := r.Players[id]
+		if q.Spymaster {
+			continue
+		}
+		guessers++
+
+		if vote, ok := r.RevealVotes[id]; ok && vote == index {
+			voters = append(voters, q.Nickname)
+		}
+	}
+
+	if 100*len(voters) < r.RevealShare*guessers {
+		return nil, false
+	}
+
+	return voters, true
+}
+
+// TileVotes returns the players who have voted to reveal the tile, in team
+// order.
+func (r *Room) TileVotes(row, col int) []PlayerID {
+	index, ok := r.Board.Index(row, col)
+	if !ok || len(r.RevealVotes) == 0 {
+		return nil
+	}
+
+	var votes []PlayerID
+	for _, members := range r.Teams {
+		for _, id := range members {
+			if vote, ok := r.RevealVotes[id]; ok && vote == index {
+				votes = append(votes, id)
+			}
+		}
+	}
+
+	return votes
+}
+
+// ChangeRevealShare sets the percentage of the current team's guessers who
+// must vote for a tile to reveal it. Zero turns voting off. Changing the share
+// clears all votes.
+func (r *Room) ChangeRevealShare(share int) error {
+	if share < 0 || share > 100 {
+		return ErrInvalidOption
+	}
+
+	if share == r.RevealShare {
+		return nil
+	}
+
+	r.RevealShare = share
+	r.RevealVotes = nil
+	r.Version++
+	return nil
+}
@@ -0,0 +1,87 @@
+package game
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func newVoteTestRoom(t *testing.T, share int) (r *Room, guessers []PlayerID) {
+	t.Helper()
+
+	r = newTestRoom(t, 2)
+	r.ChangeRevealShare(share)
+	assert.Equal(t, r.RevealShare, share)
+
+	for _, id := range []PlayerID{"x", "y"} {
+		r.AddPlayer(id, id)
+		r.ChangeTeam(id, r.Turn)
+	}
+
+	return r, r.Teams[r.Turn]
+}
+
+func TestVoteReveal(t *testing.T) {
+	r, guessers := newVoteTestRoom(t, 51)
+	assert.Equal(t, len(guessers), 3)
+
+	row, col := ownWord(t, r)
+	r.Reveal(guessers[0], row, col)
+	assert.Assert(t, !r.Board.Get(row, col).Revealed)
+	assert.DeepEqual(t, r.TileVotes(row, col), guessers[:1])
+
+	r.Reveal(guessers[1], row, col)
+	assert.Assert(t, r.Board.Get(row, col).Revealed)
+	assert.Equal(t, len(r.RevealVotes), 0)
+
+	e := r.Log[len(r.Log)-1]
+	assert.Equal(t, e.Type, EventReveal)
+	assert.Equal(t, e.Player, guessers[1])
+	assert.DeepEqual(t, e.Voters, []string{r.Players[guessers[0]].Nickname, r.Players[guessers[1]].Nickname})
+}
+
+func TestVoteRevealChangeVote(t *testing.T) {
+	r, guessers := newVoteTestRoom(t, 100)
+
+	row, col := ownWord(t, r)
+	r.Reveal(guessers[0], row, col)
+	r.Reveal(guessers[1], row, col)
+
+	// Voting for the same tile again withdraws the vote.
+	r.Reveal(guessers[1], row, col)
+	assert.DeepEqual(t, r.TileVotes(row, col), guessers[:1])
+
+	// Voting for another tile moves the vote.
+	other, otherCol := findTile(t, r.Board, func(tile *Tile) bool { return tile.Neutral })
+	r.Reveal(guessers[0], other, otherCol)
+	assert.Equal(t, len(r.TileVotes(row, col)), 0)
+	assert.DeepEqual(t, r.TileVotes(other, otherCol), guessers[:1])
+
+	// Spymasters don't vote, and aren't counted.
+	r.ChangeRole(guessers[2], true)
+	assert.Assert(t, !r.Board.Get(row, col).Revealed)
+	r.Reveal(guessers[0], row, col)
+	r.Reveal(guessers[1], row, col)
+	assert.Assert(t, r.Board.Get(row, col).Revealed)
+}
+
+func TestVoteRevealTurnEnd(t *testing.T) {
+	r, guessers := newVoteTestRoom(t, 100)
+
+	row, col := ownWord(t, r)
+	r.Reveal(guessers[0], row, col)
+	r.EndTurn(guessers[0])
+	assert.Equal(t, len(r.RevealVotes), 0)
+}
+
+func TestReplayVoteReveal(t *testing.T) {
+	r, guessers := newVoteTestRoom(t, 51)
+
+	row, col := ownWord(t, r)
+	for _, id := range guessers[:2] {
+		r.Reveal(id, row, col)
+	}
+
+	_, err := Play(roundTrip(t, r.Replay()))
+	assert.NilError(t, err)
+}
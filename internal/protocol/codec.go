@@ -0,0 +1,161 @@
+package protocol
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/mailru/easyjson"
+)
+
+// SubprotocolCBOR is the WebSocket subprotocol for notes encoded as CBOR
+// binary messages, with the same schema as JSON. Without a subprotocol, notes
+// are JSON text messages.
+const SubprotocolCBOR = "codies.cbor"
+
+// MaxNoteSize is the largest note a client may send, in bytes.
+const MaxNoteSize = 256 << 10
+
+// Subprotocols lists the WebSocket subprotocols the server accepts.
+var Subprotocols = []string{SubprotocolCBOR}
+
+// Codec encodes and decodes notes sent over a WebSocket.
+type Codec interface {
+	// Binary returns true if notes are sent as binary messages.
+	Binary() bool
+	MarshalServerNote(n *ServerNote) ([]byte, error)
+	UnmarshalClientNote(b []byte, n *ClientNote) error
+}
+
+// CodecFor returns the codec for a negotiated subprotocol, which may be empty.
+func CodecFor(subprotocol string) Codec {
+	if subprotocol == SubprotocolCBOR {
+		return cborCodec{}
+	}
+	return jsonCodec{}
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Binary() bool {
+	return false
+}
+
+func (jsonCodec) MarshalServerNote(n *ServerNote) ([]byte, error) {
+	return easyjson.Marshal(n)
+}
+
+func (jsonCodec) UnmarshalClientNote(b []byte, n *ClientNote) error {
+	return easyjson.Unmarshal(b, n)
+}
+
+var (
+	// Times are encoded as strings, as they are in JSON.
+	cborEnc = mustEncMode(cbor.EncOptions{Time: cbor.TimeRFC3339Nano})
+	cborDec = mustDecMode(cbor.DecOptions{})
+)
+
+type cborCodec struct{}
+
+func (cborCodec) Binary() bool {
+	return true
+}
+
+func (cborCodec) MarshalServerNote(n *ServerNote) ([]byte, error) {
+	return cborEnc.Marshal(n)
+}
+
+// UnmarshalClientNote decodes a note, converting its params to JSON, which is
+// what the note handlers expect.
+func (cborCodec) UnmarshalClientNote(b []byte, n *ClientNote) error {
+	var note struct {
+		ID      *int64          `json:"id"`
+		Method  ClientMethod    `json:"method"`
+		Version int             `json:"version"`
+		Params  cbor.RawMessage `json:"params"`
+	}
+
+	if err := cborDec.Unmarshal(b, &note); err != nil {
+		return err
+	}
+
+	n.ID = note.ID
+	n.Method = note.Method
+	n.Version = note.Version
+	n.Params = nil
+
+	if len(note.Params) == 0 {
+		return nil
+	}
+
+	params, err := cborToJSON(note.Params)
+	if err != nil {
+		return err
+	}
+	n.Params = params
+	return nil
+}
+
+// cborToJSON converts a CBOR value to the equivalent JSON.
+func cborToJSON(b []byte) ([]byte, error) {
+	var v interface{}
+	if err := cborDec.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+
+	v, err := jsonValue(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// jsonValue converts decoded CBOR into values encoding/json can marshal. CBOR
+// maps may have keys of any type; only string keys are allowed.
+func jsonValue(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			s, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("protocol: non-string map key %v", k)
+			}
+			e, err := jsonValue(e)
+			if err != nil {
+				return nil, err
+			}
+			m[s] = e
+		}
+		return m, nil
+
+	case []interface{}:
+		for i, e := range v {
+			e, err := jsonValue(e)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = e
+		}
+		return v, nil
+
+	default:
+		return v, nil
+	}
+}
+
+func mustEncMode(opts cbor.EncOptions) cbor.EncMode {
+	em, err := opts.EncMode()
+	if err != nil {
+		panic(err)
+	}
+	return em
+}
+
+func mustDecMode(opts cbor.DecOptions) cbor.DecMode {
+	dm, err := opts.DecMode()
+	if err != nil {
+		panic(err)
+	}
+	return dm
+}
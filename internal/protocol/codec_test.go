@@ -0,0 +1,75 @@
+package protocol
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/zikaeroh/codies/internal/game"
+	"gotest.tools/v3/assert"
+)
+
+func decodeJSON(t *testing.T, b []byte) interface{} {
+	t.Helper()
+	var v interface{}
+	assert.NilError(t, json.Unmarshal(b, &v))
+	return v
+}
+
+func TestCodecSchema(t *testing.T) {
+	team := game.Team(1)
+	notes := []ServerNote{
+		NewStateNote("1", "token", false, &RoomState{
+			Mode:   game.ModeClassic,
+			Teams:  [][]*StatePlayer{{{PlayerID: "1", Nickname: "one"}}, nil},
+			Winner: &team,
+			Board:  [][]*StateTile{{{Word: "OCEAN", View: &StateView{Bomb: true}}}},
+			Log:    []*StateEvent{{Type: game.EventJoin, Time: time.Unix(1600000000, 123456789).UTC(), PlayerID: "1"}},
+		}),
+		NewChatNote([]*ChatMessage{{ID: 1, Text: "hi", Team: &team, Time: time.Unix(1600000000, 0).UTC()}}),
+		NewResultNote(3, ResultNotYourTurn, "game: not your turn"),
+	}
+
+	jsonCodec := CodecFor("")
+	cborCodec := CodecFor(SubprotocolCBOR)
+	assert.Assert(t, !jsonCodec.Binary())
+	assert.Assert(t, cborCodec.Binary())
+
+	for _, n := range notes {
+		j, err := jsonCodec.MarshalServerNote(&n)
+		assert.NilError(t, err)
+
+		c, err := cborCodec.MarshalServerNote(&n)
+		assert.NilError(t, err)
+
+		converted, err := cborToJSON(c)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, decodeJSON(t, converted), decodeJSON(t, j))
+	}
+}
+
+func TestCBORClientNote(t *testing.T) {
+	id := int64(7)
+	b, err := cbor.Marshal(map[string]interface{}{
+		"id":      id,
+		"method":  "reveal",
+		"version": 3,
+		"params":  map[string]interface{}{"row": 1, "col": 2},
+	})
+	assert.NilError(t, err)
+
+	var note ClientNote
+	assert.NilError(t, CodecFor(SubprotocolCBOR).UnmarshalClientNote(b, &note))
+	assert.DeepEqual(t, note.ID, &id)
+	assert.Equal(t, note.Method, RevealMethod)
+	assert.Equal(t, note.Version, 3)
+
+	var params RevealParams
+	assert.NilError(t, json.Unmarshal(note.Params, &params))
+	assert.Equal(t, params, RevealParams{Row: 1, Col: 2})
+
+	b, err = cbor.Marshal(map[interface{}]interface{}{"method": "reveal", "params": map[interface{}]interface{}{1: 2}})
+	assert.NilError(t, err)
+	assert.ErrorContains(t, CodecFor(SubprotocolCBOR).UnmarshalClientNote(b, &note), "non-string map key")
+}
@@ -10,28 +10,39 @@ import (
 // See protocol/index.ts.
 
 //go:generate go run github.com/mailru/easyjson/easyjson -disallow_unknown_fields protocol.go
+//go:generate go run ./schemagen -ts ../../frontend/src/protocol/generated.ts -schema schema.json
 
 type ExistsQuery struct {
 	RoomID string `queryparam:"roomID"`
 }
 
+type ReplayQuery struct {
+	Token string `queryparam:"token"` // Reconnect token or room key.
+}
+
 //easyjson:json
 type RoomRequest struct {
 	RoomName string `json:"roomName"`
-	RoomPass string `json:"roomPass"`
+	RoomPass string `json:"roomPass"` // Not needed to create or join public rooms.
 	Create   bool   `json:"create"`
+	Public   bool   `json:"public,omitempty"` // List the new room in the lobby.
+	Invite   string `json:"invite,omitempty"` // Invite token; joins the room it's for, in place of a name and password.
 }
 
-func (r *RoomRequest) Valid() (msg string, valid bool) {
+func (r *RoomRequest) Valid(maxNameLen int) (msg string, valid bool) {
+	if r.Invite != "" && !r.Create {
+		return "", true
+	}
+
 	if len(r.RoomName) == 0 {
 		return "Room name cannot be empty.", false
 	}
 
-	if len(r.RoomName) > 20 {
+	if len(r.RoomName) > maxNameLen {
 		return "Room name too long.", false
 	}
 
-	if len(r.RoomPass) == 0 {
+	if len(r.RoomPass) == 0 && r.Create && !r.Public {
 		return "Room pass cannot be empty.", false
 	}
 
@@ -41,6 +52,7 @@ func (r *RoomRequest) Valid() (msg string, valid bool) {
 //easyjson:json
 type RoomResponse struct {
 	ID    *string `json:"id,omitempty"`
+	Key   *string `json:"key,omitempty"` // Needed to connect as a new player; not given to invite joins, which use the invite.
 	Error *string `json:"error,omitempty"`
 }
 
@@ -55,13 +67,62 @@ type StatsResponse struct {
 	Clients int `json:"clients"`
 }
 
+type RoomsQuery struct {
+	Offset int `queryparam:"offset"`
+	Limit  int `queryparam:"limit"` // Defaults to, and is capped at, MaxRoomsLimit.
+}
+
+// MaxRoomsLimit is the most rooms listed in a RoomsResponse.
+const MaxRoomsLimit = 50
+
+// RoomsResponse is a page of the public rooms, with those with the most
+// players first.
+//
+//easyjson:json
+type RoomsResponse struct {
+	Rooms []*LobbyRoom `json:"rooms"`
+	Total int          `json:"total"` // Public rooms on all pages.
+}
+
+//easyjson:json
+type LobbyRoom struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Players    int       `json:"players"`
+	Teams      []int     `json:"teams"` // Players on each team.
+	Spectators int       `json:"spectators"`
+	Mode       game.Mode `json:"mode"`
+	InProgress bool      `json:"inProgress"` // A move has been made in a game which isn't over.
+	Packs      []string  `json:"packs"`      // Enabled packs.
+}
+
+// InviteRequest asks for an invite to a room.
+//
+//easyjson:json
+type InviteRequest struct {
+	Expires   int        `json:"expires,omitempty"`   // Seconds until the invite expires; defaults to, and is capped at, the server's limit.
+	SingleUse bool       `json:"singleUse,omitempty"` // The invite is used up by the first player to join with it.
+	Team      *game.Team `json:"team,omitempty"`      // Players joining with the invite are put on this team.
+}
+
+//easyjson:json
+type InviteResponse struct {
+	Token   string    `json:"token"`
+	Expires time.Time `json:"expires"`
+}
+
 type WSQuery struct {
-	RoomID   string `queryparam:"roomID"`
-	Nickname string `queryparam:"nickname"`
+	RoomID    string `queryparam:"roomID"` // Not needed with an invite.
+	Key       string `queryparam:"key"`    // Room key from RoomResponse; not needed with an invite or for public rooms.
+	Nickname  string `queryparam:"nickname"`
+	Token     string `queryparam:"token"`  // Reconnect token from a previous state note.
+	Invite    string `queryparam:"invite"` // Invite token; ignored when reconnecting.
+	Spectator bool   `queryparam:"spectator"`
+	Patches   bool   `queryparam:"patches"` // Receive patches against acknowledged states instead of full states.
 }
 
-func (w *WSQuery) Valid() (msg string, valid bool) {
-	if w.RoomID == "" {
+func (w *WSQuery) Valid(maxNicknameLen int) (msg string, valid bool) {
+	if w.RoomID == "" && w.Invite == "" {
 		return "Room ID cannot be empty.", false
 	}
 
@@ -69,7 +130,7 @@ func (w *WSQuery) Valid() (msg string, valid bool) {
 		return "Nickname cannot be empty.", false
 	}
 
-	if len(w.Nickname) > 16 {
+	if len(w.Nickname) > maxNicknameLen {
 		return "Nickname too long.", false
 	}
 
@@ -78,6 +139,7 @@ func (w *WSQuery) Valid() (msg string, valid bool) {
 
 //easyjson:json
 type ClientNote struct {
+	ID      *int64              `json:"id,omitempty"`  // If set, a result note with this ID is sent in reply.
 	Method  ClientMethod        `json:"method,intern"` //nolint:staticcheck
 	Version int                 `json:"version"`
 	Params  easyjson.RawMessage `json:"params"`
@@ -88,7 +150,9 @@ type ClientMethod string
 const NewGameMethod = ClientMethod("newGame")
 
 //easyjson:json
-type NewGameParams struct{}
+type NewGameParams struct {
+	Seed *string `json:"seed,omitempty"` // Deals the game from this seed, if set. Decimal, as seeds use 63 bits.
+}
 
 const EndTurnMethod = ClientMethod("endTurn")
 
@@ -127,6 +191,7 @@ const ChangeRoleMethod = ClientMethod("changeRole")
 //easyjson:json
 type ChangeRoleParams struct {
 	Spymaster bool `json:"spymaster"`
+	Spectator bool `json:"spectator,omitempty"` // Watches the game instead of playing.
 }
 
 const ChangePackMethod = ClientMethod("changePack")
@@ -183,33 +248,279 @@ type ChangeHideBombParams struct {
 	HideBomb bool `json:"hideBomb"`
 }
 
-func NewStateNote(playerID game.PlayerID, s *RoomState) ServerNote {
+const ChangeNumTeamsMethod = ClientMethod("changeNumTeams")
+
+//easyjson:json
+type ChangeNumTeamsParams struct {
+	NumTeams int `json:"numTeams"`
+}
+
+const ChangeBoardSizeMethod = ClientMethod("changeBoardSize")
+
+//easyjson:json
+type ChangeBoardSizeParams struct {
+	Rows int `json:"rows"`
+	Cols int `json:"cols"`
+}
+
+const ChangeModeMethod = ClientMethod("changeMode")
+
+//easyjson:json
+type ChangeModeParams struct {
+	Mode game.Mode `json:"mode"`
+}
+
+const GiveClueMethod = ClientMethod("giveClue")
+
+//easyjson:json
+type GiveClueParams struct {
+	Word      string `json:"word"`
+	Count     int    `json:"count"`
+	Unlimited bool   `json:"unlimited"`
+}
+
+const UndoMethod = ClientMethod("undo")
+
+//easyjson:json
+type UndoParams struct{}
+
+const ChangeUndoRuleMethod = ClientMethod("changeUndoRule")
+
+//easyjson:json
+type ChangeUndoRuleParams struct {
+	Rule game.UndoRule `json:"rule"`
+}
+
+const ChangeRevealShareMethod = ClientMethod("changeRevealShare")
+
+//easyjson:json
+type ChangeRevealShareParams struct {
+	Share int `json:"share"` // Percentage of guessers who must vote to reveal a tile; zero disables voting.
+}
+
+const ChatMethod = ClientMethod("chat")
+
+//easyjson:json
+type ChatParams struct {
+	Text string `json:"text"`
+	Team bool   `json:"team"` // Only sent to the player's team.
+}
+
+const ChangeHideTeamChatMethod = ClientMethod("changeHideTeamChat")
+
+//easyjson:json
+type ChangeHideTeamChatParams struct {
+	HideTeamChat bool `json:"hideTeamChat"` // Spymasters can't see or send team-only chat.
+}
+
+// AckMethod acknowledges that a client has applied the state with a version, so
+// that later states may be sent as patches against it. A version of -1 asks
+// for a full state.
+const AckMethod = ClientMethod("ack")
+
+//easyjson:json
+type AckParams struct {
+	Version int `json:"version"`
+}
+
+const ChangeStreamSafeMethod = ClientMethod("changeStreamSafe")
+
+//easyjson:json
+type ChangeStreamSafeParams struct {
+	StreamSafe bool `json:"streamSafe"` // Spectators never see the key, even once the game is over.
+}
+
+// The following methods may only be used by the room's host.
+
+const ChangeHostMethod = ClientMethod("changeHost")
+
+//easyjson:json
+type ChangeHostParams struct {
+	PlayerID game.PlayerID `json:"playerID"`
+}
+
+const KickMethod = ClientMethod("kick")
+
+//easyjson:json
+type KickParams struct {
+	PlayerID game.PlayerID `json:"playerID"`
+}
+
+const BanMethod = ClientMethod("ban")
+
+//easyjson:json
+type BanParams struct {
+	PlayerID game.PlayerID `json:"playerID,omitempty"` // Bans the player's reconnect token.
+	Nickname string        `json:"nickname,omitempty"`
+}
+
+const ChangeLockedMethod = ClientMethod("changeLocked")
+
+//easyjson:json
+type ChangeLockedParams struct {
+	Locked bool `json:"locked"`
+}
+
+const ChangeHostOnlyMethod = ClientMethod("changeHostOnly")
+
+//easyjson:json
+type ChangeHostOnlyParams struct {
+	HostOnly bool `json:"hostOnly"` // Only the host may change settings or start new games.
+}
+
+const RevokeInvitesMethod = ClientMethod("revokeInvites")
+
+// RevokeInvitesParams revokes all of the room's outstanding invites.
+//
+//easyjson:json
+type RevokeInvitesParams struct{}
+
+func NewStateNote(playerID game.PlayerID, token string, spectator bool, s *RoomState) ServerNote {
 	return ServerNote{
 		Method: "state",
 		Params: &State{
 			PlayerID:  playerID,
+			Token:     token,
+			Spectator: spectator,
 			RoomState: s,
 		},
 	}
 }
 
+func NewChatNote(messages []*ChatMessage) ServerNote {
+	return ServerNote{
+		Method: "chat",
+		Params: &Chat{
+			Messages: messages,
+		},
+	}
+}
+
+func NewPatchNote(from, to int, ops []*PatchOp) ServerNote {
+	return ServerNote{
+		Method: "patch",
+		Params: &Patch{
+			From: from,
+			To:   to,
+			Ops:  ops,
+		},
+	}
+}
+
+func NewResultNote(id int64, code ResultCode, message string) ServerNote {
+	return ServerNote{
+		Method: "result",
+		Params: &Result{
+			ID:      id,
+			OK:      code == "",
+			Code:    code,
+			Message: message,
+		},
+	}
+}
+
+// CloseEvent is sent to clients using server-sent events when the server
+// closes their connection, in place of a WebSocket close frame.
+//
+//easyjson:json
+type CloseEvent struct {
+	Code   int    `json:"code"`
+	Reason string `json:"reason"`
+}
+
+// ResultCode says why a client note had no effect.
+type ResultCode string
+
+const (
+	ResultNotYourTurn   = ResultCode("not_your_turn")
+	ResultStaleVersion  = ResultCode("stale_version") // The current state is sent before the result.
+	ResultInvalidParams = ResultCode("invalid_params")
+	ResultForbidden     = ResultCode("forbidden")
+	ResultRateLimited   = ResultCode("rate_limited")
+)
+
+// Result replies to a client note which had an ID.
+//
+//easyjson:json
+type Result struct {
+	ID      int64      `json:"id"`
+	OK      bool       `json:"ok"`
+	Code    ResultCode `json:"code,omitempty"` // Set if not OK.
+	Message string     `json:"message,omitempty"`
+}
+
+// Patch changes the state with version From into the state with version To.
+//
+//easyjson:json
+type Patch struct {
+	From int        `json:"from"`
+	To   int        `json:"to"`
+	Ops  []*PatchOp `json:"ops"`
+}
+
+// PatchOp is a JSON Patch (RFC 6902) operation on a State. Only add, remove
+// and replace are used.
+//
+//easyjson:json
+type PatchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+//easyjson:json
+type Chat struct {
+	Messages []*ChatMessage `json:"messages"`
+}
+
+//easyjson:json
+type ChatMessage struct {
+	ID       int64         `json:"id"` // Increases with each message in the room.
+	PlayerID game.PlayerID `json:"playerID"`
+	Nickname string        `json:"nickname"`
+	Text     string        `json:"text"`
+	Team     *game.Team    `json:"team"` // Set for team-only messages.
+	Time     time.Time     `json:"time"`
+}
+
 //easyjson:json
 type State struct {
 	PlayerID  game.PlayerID `json:"playerID"`
+	Token     string        `json:"token"` // Reconnects as this player.
+	Spectator bool          `json:"spectator"`
 	RoomState *RoomState    `json:"roomState"`
 }
 
 //easyjson:json
 type RoomState struct {
-	Version   int              `json:"version"`
-	Teams     [][]*StatePlayer `json:"teams"`
-	Turn      game.Team        `json:"turn"`
-	Winner    *game.Team       `json:"winner"`
-	Board     [][]*StateTile   `json:"board"`
-	WordsLeft []int            `json:"wordsLeft"`
-	Lists     []*StateWordList `json:"lists"`
-	Timer     *StateTimer      `json:"timer"`
-	HideBomb  bool             `json:"hideBomb"`
+	Mode         game.Mode        `json:"mode"`
+	Version      int              `json:"version"`
+	Teams        [][]*StatePlayer `json:"teams"`
+	NumTeams     int              `json:"numTeams"`
+	Turn         game.Team        `json:"turn"`
+	Winner       *game.Team       `json:"winner"`
+	Eliminated   []bool           `json:"eliminated"`
+	Duet         *StateDuet       `json:"duet"`
+	Clue         *StateClue       `json:"clue"`
+	Board        [][]*StateTile   `json:"board"`
+	WordsLeft    []int            `json:"wordsLeft"`
+	Lists        []*StateWordList `json:"lists"`
+	Log          []*StateEvent    `json:"log"`
+	Seed         *string          `json:"seed"` // Only shown once the game is over, as it reveals the key.
+	Undo         *StateUndo       `json:"undo"`
+	UndoRule     game.UndoRule    `json:"undoRule"`
+	RevealShare  int              `json:"revealShare"`
+	Timer        *StateTimer      `json:"timer"`
+	HideBomb     bool             `json:"hideBomb"`
+	Rows         int              `json:"rows"` // Board size for the next game.
+	Cols         int              `json:"cols"`
+	Host         game.PlayerID    `json:"host"`
+	Locked       bool             `json:"locked"`
+	HostOnly     bool             `json:"hostOnly"`
+	StreamSafe   bool             `json:"streamSafe"`
+	Spectators   []*StatePlayer   `json:"spectators"`
+	HideTeamChat bool             `json:"hideTeamChat"`
+	Invites      int              `json:"invites"` // Outstanding invites, which the host may revoke.
 }
 
 //easyjson:json
@@ -217,13 +528,16 @@ type StatePlayer struct {
 	PlayerID  game.PlayerID `json:"playerID"`
 	Nickname  string        `json:"nickname"`
 	Spymaster bool          `json:"spymaster"`
+	Away      bool          `json:"away"` // Disconnected, but may still reconnect.
 }
 
 //easyjson:json
 type StateTile struct {
-	Word     string     `json:"word"`
-	Revealed bool       `json:"revealed"`
-	View     *StateView `json:"view"`
+	Word     string          `json:"word"`
+	Revealed bool            `json:"revealed"`
+	View     *StateView      `json:"view"`
+	Marked   []bool          `json:"marked,omitempty"` // Duet sides whose key card shows this tile as a bystander.
+	Votes    []game.PlayerID `json:"votes,omitempty"`  // Guessers voting to reveal this tile.
 }
 
 //easyjson:json
@@ -241,6 +555,52 @@ type StateWordList struct {
 	Enabled bool   `json:"enabled"`
 }
 
+//easyjson:json
+type StateDuet struct {
+	TimerTokens int  `json:"timerTokens"`
+	Won         bool `json:"won"`
+	Lost        bool `json:"lost"`
+}
+
+//easyjson:json
+type StateClue struct {
+	Team        game.Team `json:"team"`
+	Word        string    `json:"word"`
+	Count       int       `json:"count"`
+	Unlimited   bool      `json:"unlimited"`
+	GuessesLeft *int      `json:"guessesLeft"` // Unset if guesses are unlimited.
+}
+
+//easyjson:json
+type StateEvent struct {
+	Type      game.EventType    `json:"type"`
+	Time      time.Time         `json:"time"`
+	Turn      game.Team         `json:"turn"`
+	PlayerID  game.PlayerID     `json:"playerID,omitempty"` // Unset if not caused by a player.
+	Nickname  string            `json:"nickname,omitempty"`
+	Tile      *StateEventTile   `json:"tile,omitempty"`
+	Clue      *StateClue        `json:"clue,omitempty"`
+	Team      *game.Team        `json:"team,omitempty"`
+	Teams     [][]game.PlayerID `json:"teams,omitempty"`
+	Spymaster *bool             `json:"spymaster,omitempty"`
+	Undone    *StateEvent       `json:"undone,omitempty"`
+	Voters    []string          `json:"voters,omitempty"`
+}
+
+//easyjson:json
+type StateUndo struct {
+	Event *StateEvent     `json:"event"` // The move which would be undone.
+	Votes []game.PlayerID `json:"votes"`
+}
+
+//easyjson:json
+type StateEventTile struct {
+	Row  int        `json:"row"`
+	Col  int        `json:"col"`
+	Word string     `json:"word"`
+	View *StateView `json:"view"`
+}
+
 //easyjson:json
 type StateTimer struct {
 	TurnTime int       `json:"turnTime"`
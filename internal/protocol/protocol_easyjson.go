@@ -18,7 +18,70 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol(in *jlexer.Lexer, out *TimeResponse) {
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol(in *jlexer.Lexer, out *UndoParams) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol(out *jwriter.Writer, in UndoParams) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UndoParams) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UndoParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UndoParams) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UndoParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol1(in *jlexer.Lexer, out *TimeResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -55,7 +118,7 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol(in *jlexer.Le
 		in.Consumed()
 	}
 }
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol(out *jwriter.Writer, in TimeResponse) {
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol1(out *jwriter.Writer, in TimeResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -70,27 +133,27 @@ func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol(out *jwriter.
 // MarshalJSON supports json.Marshaler interface
 func (v TimeResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol(&w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v TimeResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol(w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *TimeResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol(&r, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *TimeResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol(l, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol1(l, v)
 }
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol1(in *jlexer.Lexer, out *StatsResponse) {
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol2(in *jlexer.Lexer, out *StatsResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -127,7 +190,7 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol1(in *jlexer.L
 		in.Consumed()
 	}
 }
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol1(out *jwriter.Writer, in StatsResponse) {
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol2(out *jwriter.Writer, in StatsResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -147,27 +210,27 @@ func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol1(out *jwriter
 // MarshalJSON supports json.Marshaler interface
 func (v StatsResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol1(&w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v StatsResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol1(w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *StatsResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol1(&r, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *StatsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol1(l, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol2(l, v)
 }
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol2(in *jlexer.Lexer, out *StateWordList) {
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol3(in *jlexer.Lexer, out *StateWordList) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -208,7 +271,7 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol2(in *jlexer.L
 		in.Consumed()
 	}
 }
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol2(out *jwriter.Writer, in StateWordList) {
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol3(out *jwriter.Writer, in StateWordList) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -238,27 +301,27 @@ func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol2(out *jwriter
 // MarshalJSON supports json.Marshaler interface
 func (v StateWordList) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol2(&w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v StateWordList) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol2(w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *StateWordList) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol2(&r, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *StateWordList) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol2(l, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol3(l, v)
 }
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol3(in *jlexer.Lexer, out *StateView) {
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol4(in *jlexer.Lexer, out *StateView) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -297,7 +360,7 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol3(in *jlexer.L
 		in.Consumed()
 	}
 }
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol3(out *jwriter.Writer, in StateView) {
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol4(out *jwriter.Writer, in StateView) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -322,27 +385,148 @@ func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol3(out *jwriter
 // MarshalJSON supports json.Marshaler interface
 func (v StateView) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol3(&w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v StateView) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol3(w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *StateView) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol3(&r, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *StateView) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol3(l, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol4(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol5(in *jlexer.Lexer, out *StateUndo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "event":
+			if in.IsNull() {
+				in.Skip()
+				out.Event = nil
+			} else {
+				if out.Event == nil {
+					out.Event = new(StateEvent)
+				}
+				(*out.Event).UnmarshalEasyJSON(in)
+			}
+		case "votes":
+			if in.IsNull() {
+				in.Skip()
+				out.Votes = nil
+			} else {
+				in.Delim('[')
+				if out.Votes == nil {
+					if !in.IsDelim(']') {
+						out.Votes = make([]string, 0, 4)
+					} else {
+						out.Votes = []string{}
+					}
+				} else {
+					out.Votes = (out.Votes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 string
+					v1 = string(in.String())
+					out.Votes = append(out.Votes, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol5(out *jwriter.Writer, in StateUndo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"event\":"
+		out.RawString(prefix[1:])
+		if in.Event == nil {
+			out.RawString("null")
+		} else {
+			(*in.Event).MarshalEasyJSON(out)
+		}
+	}
+	{
+		const prefix string = ",\"votes\":"
+		out.RawString(prefix)
+		if in.Votes == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Votes {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v3))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v StateUndo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v StateUndo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *StateUndo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *StateUndo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol5(l, v)
 }
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol4(in *jlexer.Lexer, out *StateTimer) {
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol6(in *jlexer.Lexer, out *StateTimer) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -381,7 +565,7 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol4(in *jlexer.L
 		in.Consumed()
 	}
 }
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol4(out *jwriter.Writer, in StateTimer) {
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol6(out *jwriter.Writer, in StateTimer) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -401,27 +585,27 @@ func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol4(out *jwriter
 // MarshalJSON supports json.Marshaler interface
 func (v StateTimer) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol4(&w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol6(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v StateTimer) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol4(w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol6(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *StateTimer) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol4(&r, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol6(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *StateTimer) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol4(l, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol6(l, v)
 }
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol5(in *jlexer.Lexer, out *StateTile) {
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol7(in *jlexer.Lexer, out *StateTile) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -454,6 +638,52 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol5(in *jlexer.L
 				}
 				(*out.View).UnmarshalEasyJSON(in)
 			}
+		case "marked":
+			if in.IsNull() {
+				in.Skip()
+				out.Marked = nil
+			} else {
+				in.Delim('[')
+				if out.Marked == nil {
+					if !in.IsDelim(']') {
+						out.Marked = make([]bool, 0, 64)
+					} else {
+						out.Marked = []bool{}
+					}
+				} else {
+					out.Marked = (out.Marked)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 bool
+					v4 = bool(in.Bool())
+					out.Marked = append(out.Marked, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "votes":
+			if in.IsNull() {
+				in.Skip()
+				out.Votes = nil
+			} else {
+				in.Delim('[')
+				if out.Votes == nil {
+					if !in.IsDelim(']') {
+						out.Votes = make([]string, 0, 4)
+					} else {
+						out.Votes = []string{}
+					}
+				} else {
+					out.Votes = (out.Votes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v5 string
+					v5 = string(in.String())
+					out.Votes = append(out.Votes, v5)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -468,7 +698,7 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol5(in *jlexer.L
 		in.Consumed()
 	}
 }
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol5(out *jwriter.Writer, in StateTile) {
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol7(out *jwriter.Writer, in StateTile) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -491,33 +721,61 @@ func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol5(out *jwriter
 			(*in.View).MarshalEasyJSON(out)
 		}
 	}
+	if len(in.Marked) != 0 {
+		const prefix string = ",\"marked\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v6, v7 := range in.Marked {
+				if v6 > 0 {
+					out.RawByte(',')
+				}
+				out.Bool(bool(v7))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Votes) != 0 {
+		const prefix string = ",\"votes\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v8, v9 := range in.Votes {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v9))
+			}
+			out.RawByte(']')
+		}
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v StateTile) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol5(&w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol7(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v StateTile) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol5(w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol7(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *StateTile) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol5(&r, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol7(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *StateTile) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol5(l, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol7(l, v)
 }
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol6(in *jlexer.Lexer, out *StatePlayer) {
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol8(in *jlexer.Lexer, out *StatePlayer) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -542,6 +800,8 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol6(in *jlexer.L
 			out.Nickname = string(in.String())
 		case "spymaster":
 			out.Spymaster = bool(in.Bool())
+		case "away":
+			out.Away = bool(in.Bool())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -556,7 +816,7 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol6(in *jlexer.L
 		in.Consumed()
 	}
 }
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol6(out *jwriter.Writer, in StatePlayer) {
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol8(out *jwriter.Writer, in StatePlayer) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -575,33 +835,38 @@ func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol6(out *jwriter
 		out.RawString(prefix)
 		out.Bool(bool(in.Spymaster))
 	}
+	{
+		const prefix string = ",\"away\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Away))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v StatePlayer) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol6(&w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol8(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v StatePlayer) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol6(w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol8(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *StatePlayer) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol6(&r, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol8(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *StatePlayer) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol6(l, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol8(l, v)
 }
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol7(in *jlexer.Lexer, out *State) {
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol9(in *jlexer.Lexer, out *StateEventTile) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -620,17 +885,21 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol7(in *jlexer.L
 			continue
 		}
 		switch key {
-		case "playerID":
-			out.PlayerID = string(in.String())
-		case "roomState":
+		case "row":
+			out.Row = int(in.Int())
+		case "col":
+			out.Col = int(in.Int())
+		case "word":
+			out.Word = string(in.String())
+		case "view":
 			if in.IsNull() {
 				in.Skip()
-				out.RoomState = nil
+				out.View = nil
 			} else {
-				if out.RoomState == nil {
-					out.RoomState = new(RoomState)
+				if out.View == nil {
+					out.View = new(StateView)
 				}
-				(*out.RoomState).UnmarshalEasyJSON(in)
+				(*out.View).UnmarshalEasyJSON(in)
 			}
 		default:
 			in.AddError(&jlexer.LexerError{
@@ -646,140 +915,61 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol7(in *jlexer.L
 		in.Consumed()
 	}
 }
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol7(out *jwriter.Writer, in State) {
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol9(out *jwriter.Writer, in StateEventTile) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"playerID\":"
+		const prefix string = ",\"row\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.PlayerID))
+		out.Int(int(in.Row))
 	}
 	{
-		const prefix string = ",\"roomState\":"
+		const prefix string = ",\"col\":"
 		out.RawString(prefix)
-		if in.RoomState == nil {
-			out.RawString("null")
-		} else {
-			(*in.RoomState).MarshalEasyJSON(out)
-		}
-	}
-	out.RawByte('}')
-}
-
-// MarshalJSON supports json.Marshaler interface
-func (v State) MarshalJSON() ([]byte, error) {
-	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol7(&w, v)
-	return w.Buffer.BuildBytes(), w.Error
-}
-
-// MarshalEasyJSON supports easyjson.Marshaler interface
-func (v State) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol7(w, v)
-}
-
-// UnmarshalJSON supports json.Unmarshaler interface
-func (v *State) UnmarshalJSON(data []byte) error {
-	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol7(&r, v)
-	return r.Error()
-}
-
-// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *State) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol7(l, v)
-}
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol8(in *jlexer.Lexer, out *ServerNote) {
-	isTopLevel := in.IsStart()
-	if in.IsNull() {
-		if isTopLevel {
-			in.Consumed()
-		}
-		in.Skip()
-		return
-	}
-	in.Delim('{')
-	for !in.IsDelim('}') {
-		key := in.UnsafeFieldName(false)
-		in.WantColon()
-		if in.IsNull() {
-			in.Skip()
-			in.WantComma()
-			continue
-		}
-		switch key {
-		case "method":
-			out.Method = ServerMethod(in.String())
-		case "params":
-			if m, ok := out.Params.(easyjson.Unmarshaler); ok {
-				m.UnmarshalEasyJSON(in)
-			} else if m, ok := out.Params.(json.Unmarshaler); ok {
-				_ = m.UnmarshalJSON(in.Raw())
-			} else {
-				out.Params = in.Interface()
-			}
-		default:
-			in.AddError(&jlexer.LexerError{
-				Offset: in.GetPos(),
-				Reason: "unknown field",
-				Data:   key,
-			})
-		}
-		in.WantComma()
-	}
-	in.Delim('}')
-	if isTopLevel {
-		in.Consumed()
+		out.Int(int(in.Col))
 	}
-}
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol8(out *jwriter.Writer, in ServerNote) {
-	out.RawByte('{')
-	first := true
-	_ = first
 	{
-		const prefix string = ",\"method\":"
-		out.RawString(prefix[1:])
-		out.String(string(in.Method))
+		const prefix string = ",\"word\":"
+		out.RawString(prefix)
+		out.String(string(in.Word))
 	}
 	{
-		const prefix string = ",\"params\":"
+		const prefix string = ",\"view\":"
 		out.RawString(prefix)
-		if m, ok := in.Params.(easyjson.Marshaler); ok {
-			m.MarshalEasyJSON(out)
-		} else if m, ok := in.Params.(json.Marshaler); ok {
-			out.Raw(m.MarshalJSON())
+		if in.View == nil {
+			out.RawString("null")
 		} else {
-			out.Raw(json.Marshal(in.Params))
+			(*in.View).MarshalEasyJSON(out)
 		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ServerNote) MarshalJSON() ([]byte, error) {
+func (v StateEventTile) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol8(&w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol9(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ServerNote) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol8(w, v)
+func (v StateEventTile) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol9(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ServerNote) UnmarshalJSON(data []byte) error {
+func (v *StateEventTile) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol8(&r, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol9(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ServerNote) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol8(l, v)
+func (v *StateEventTile) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol9(l, v)
 }
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol9(in *jlexer.Lexer, out *RoomState) {
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol10(in *jlexer.Lexer, out *StateEvent) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -798,190 +988,135 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol9(in *jlexer.L
 			continue
 		}
 		switch key {
-		case "version":
-			out.Version = int(in.Int())
-		case "teams":
+		case "type":
+			out.Type = game.EventType(in.String())
+		case "time":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Time).UnmarshalJSON(data))
+			}
+		case "turn":
+			out.Turn = game.Team(in.Int())
+		case "playerID":
+			out.PlayerID = string(in.String())
+		case "nickname":
+			out.Nickname = string(in.String())
+		case "tile":
 			if in.IsNull() {
 				in.Skip()
-				out.Teams = nil
+				out.Tile = nil
 			} else {
-				in.Delim('[')
-				if out.Teams == nil {
-					if !in.IsDelim(']') {
-						out.Teams = make([][]*StatePlayer, 0, 2)
-					} else {
-						out.Teams = [][]*StatePlayer{}
-					}
-				} else {
-					out.Teams = (out.Teams)[:0]
+				if out.Tile == nil {
+					out.Tile = new(StateEventTile)
 				}
-				for !in.IsDelim(']') {
-					var v1 []*StatePlayer
-					if in.IsNull() {
-						in.Skip()
-						v1 = nil
-					} else {
-						in.Delim('[')
-						if v1 == nil {
-							if !in.IsDelim(']') {
-								v1 = make([]*StatePlayer, 0, 8)
-							} else {
-								v1 = []*StatePlayer{}
-							}
-						} else {
-							v1 = (v1)[:0]
-						}
-						for !in.IsDelim(']') {
-							var v2 *StatePlayer
-							if in.IsNull() {
-								in.Skip()
-								v2 = nil
-							} else {
-								if v2 == nil {
-									v2 = new(StatePlayer)
-								}
-								(*v2).UnmarshalEasyJSON(in)
-							}
-							v1 = append(v1, v2)
-							in.WantComma()
-						}
-						in.Delim(']')
-					}
-					out.Teams = append(out.Teams, v1)
-					in.WantComma()
+				(*out.Tile).UnmarshalEasyJSON(in)
+			}
+		case "clue":
+			if in.IsNull() {
+				in.Skip()
+				out.Clue = nil
+			} else {
+				if out.Clue == nil {
+					out.Clue = new(StateClue)
 				}
-				in.Delim(']')
+				(*out.Clue).UnmarshalEasyJSON(in)
 			}
-		case "turn":
-			out.Turn = game.Team(in.Int())
-		case "winner":
+		case "team":
 			if in.IsNull() {
 				in.Skip()
-				out.Winner = nil
+				out.Team = nil
 			} else {
-				if out.Winner == nil {
-					out.Winner = new(game.Team)
+				if out.Team == nil {
+					out.Team = new(game.Team)
 				}
-				*out.Winner = game.Team(in.Int())
+				*out.Team = game.Team(in.Int())
 			}
-		case "board":
+		case "teams":
 			if in.IsNull() {
 				in.Skip()
-				out.Board = nil
+				out.Teams = nil
 			} else {
 				in.Delim('[')
-				if out.Board == nil {
+				if out.Teams == nil {
 					if !in.IsDelim(']') {
-						out.Board = make([][]*StateTile, 0, 2)
+						out.Teams = make([][]string, 0, 2)
 					} else {
-						out.Board = [][]*StateTile{}
+						out.Teams = [][]string{}
 					}
 				} else {
-					out.Board = (out.Board)[:0]
+					out.Teams = (out.Teams)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v3 []*StateTile
+					var v10 []string
 					if in.IsNull() {
 						in.Skip()
-						v3 = nil
+						v10 = nil
 					} else {
 						in.Delim('[')
-						if v3 == nil {
+						if v10 == nil {
 							if !in.IsDelim(']') {
-								v3 = make([]*StateTile, 0, 8)
+								v10 = make([]string, 0, 4)
 							} else {
-								v3 = []*StateTile{}
+								v10 = []string{}
 							}
 						} else {
-							v3 = (v3)[:0]
+							v10 = (v10)[:0]
 						}
 						for !in.IsDelim(']') {
-							var v4 *StateTile
-							if in.IsNull() {
-								in.Skip()
-								v4 = nil
-							} else {
-								if v4 == nil {
-									v4 = new(StateTile)
-								}
-								(*v4).UnmarshalEasyJSON(in)
-							}
-							v3 = append(v3, v4)
+							var v11 string
+							v11 = string(in.String())
+							v10 = append(v10, v11)
 							in.WantComma()
 						}
 						in.Delim(']')
 					}
-					out.Board = append(out.Board, v3)
+					out.Teams = append(out.Teams, v10)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "wordsLeft":
+		case "spymaster":
 			if in.IsNull() {
 				in.Skip()
-				out.WordsLeft = nil
+				out.Spymaster = nil
 			} else {
-				in.Delim('[')
-				if out.WordsLeft == nil {
-					if !in.IsDelim(']') {
-						out.WordsLeft = make([]int, 0, 8)
-					} else {
-						out.WordsLeft = []int{}
-					}
-				} else {
-					out.WordsLeft = (out.WordsLeft)[:0]
+				if out.Spymaster == nil {
+					out.Spymaster = new(bool)
 				}
-				for !in.IsDelim(']') {
-					var v5 int
-					v5 = int(in.Int())
-					out.WordsLeft = append(out.WordsLeft, v5)
-					in.WantComma()
+				*out.Spymaster = bool(in.Bool())
+			}
+		case "undone":
+			if in.IsNull() {
+				in.Skip()
+				out.Undone = nil
+			} else {
+				if out.Undone == nil {
+					out.Undone = new(StateEvent)
 				}
-				in.Delim(']')
+				(*out.Undone).UnmarshalEasyJSON(in)
 			}
-		case "lists":
+		case "voters":
 			if in.IsNull() {
 				in.Skip()
-				out.Lists = nil
+				out.Voters = nil
 			} else {
 				in.Delim('[')
-				if out.Lists == nil {
+				if out.Voters == nil {
 					if !in.IsDelim(']') {
-						out.Lists = make([]*StateWordList, 0, 8)
+						out.Voters = make([]string, 0, 4)
 					} else {
-						out.Lists = []*StateWordList{}
+						out.Voters = []string{}
 					}
 				} else {
-					out.Lists = (out.Lists)[:0]
+					out.Voters = (out.Voters)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v6 *StateWordList
-					if in.IsNull() {
-						in.Skip()
-						v6 = nil
-					} else {
-						if v6 == nil {
-							v6 = new(StateWordList)
-						}
-						(*v6).UnmarshalEasyJSON(in)
-					}
-					out.Lists = append(out.Lists, v6)
+					var v12 string
+					v12 = string(in.String())
+					out.Voters = append(out.Voters, v12)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "timer":
-			if in.IsNull() {
-				in.Skip()
-				out.Timer = nil
-			} else {
-				if out.Timer == nil {
-					out.Timer = new(StateTimer)
-				}
-				(*out.Timer).UnmarshalEasyJSON(in)
-			}
-		case "hideBomb":
-			out.HideBomb = bool(in.Bool())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -996,168 +1131,3480 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol9(in *jlexer.L
 		in.Consumed()
 	}
 }
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol9(out *jwriter.Writer, in RoomState) {
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol10(out *jwriter.Writer, in StateEvent) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"time\":"
+		out.RawString(prefix)
+		out.Raw((in.Time).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"turn\":"
+		out.RawString(prefix)
+		out.Int(int(in.Turn))
+	}
+	if in.PlayerID != "" {
+		const prefix string = ",\"playerID\":"
+		out.RawString(prefix)
+		out.String(string(in.PlayerID))
+	}
+	if in.Nickname != "" {
+		const prefix string = ",\"nickname\":"
+		out.RawString(prefix)
+		out.String(string(in.Nickname))
+	}
+	if in.Tile != nil {
+		const prefix string = ",\"tile\":"
+		out.RawString(prefix)
+		(*in.Tile).MarshalEasyJSON(out)
+	}
+	if in.Clue != nil {
+		const prefix string = ",\"clue\":"
+		out.RawString(prefix)
+		(*in.Clue).MarshalEasyJSON(out)
+	}
+	if in.Team != nil {
+		const prefix string = ",\"team\":"
+		out.RawString(prefix)
+		out.Int(int(*in.Team))
+	}
+	if len(in.Teams) != 0 {
+		const prefix string = ",\"teams\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v13, v14 := range in.Teams {
+				if v13 > 0 {
+					out.RawByte(',')
+				}
+				if v14 == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+					out.RawString("null")
+				} else {
+					out.RawByte('[')
+					for v15, v16 := range v14 {
+						if v15 > 0 {
+							out.RawByte(',')
+						}
+						out.String(string(v16))
+					}
+					out.RawByte(']')
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Spymaster != nil {
+		const prefix string = ",\"spymaster\":"
+		out.RawString(prefix)
+		out.Bool(bool(*in.Spymaster))
+	}
+	if in.Undone != nil {
+		const prefix string = ",\"undone\":"
+		out.RawString(prefix)
+		(*in.Undone).MarshalEasyJSON(out)
+	}
+	if len(in.Voters) != 0 {
+		const prefix string = ",\"voters\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v17, v18 := range in.Voters {
+				if v17 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v18))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v StateEvent) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol10(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v StateEvent) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol10(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *StateEvent) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol10(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *StateEvent) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol10(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol11(in *jlexer.Lexer, out *StateDuet) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "timerTokens":
+			out.TimerTokens = int(in.Int())
+		case "won":
+			out.Won = bool(in.Bool())
+		case "lost":
+			out.Lost = bool(in.Bool())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol11(out *jwriter.Writer, in StateDuet) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"timerTokens\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.TimerTokens))
+	}
+	{
+		const prefix string = ",\"won\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Won))
+	}
+	{
+		const prefix string = ",\"lost\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Lost))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v StateDuet) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol11(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v StateDuet) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol11(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *StateDuet) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol11(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *StateDuet) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol11(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol12(in *jlexer.Lexer, out *StateClue) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "team":
+			out.Team = game.Team(in.Int())
+		case "word":
+			out.Word = string(in.String())
+		case "count":
+			out.Count = int(in.Int())
+		case "unlimited":
+			out.Unlimited = bool(in.Bool())
+		case "guessesLeft":
+			if in.IsNull() {
+				in.Skip()
+				out.GuessesLeft = nil
+			} else {
+				if out.GuessesLeft == nil {
+					out.GuessesLeft = new(int)
+				}
+				*out.GuessesLeft = int(in.Int())
+			}
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol12(out *jwriter.Writer, in StateClue) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"team\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Team))
+	}
+	{
+		const prefix string = ",\"word\":"
+		out.RawString(prefix)
+		out.String(string(in.Word))
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Int(int(in.Count))
+	}
+	{
+		const prefix string = ",\"unlimited\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Unlimited))
+	}
+	{
+		const prefix string = ",\"guessesLeft\":"
+		out.RawString(prefix)
+		if in.GuessesLeft == nil {
+			out.RawString("null")
+		} else {
+			out.Int(int(*in.GuessesLeft))
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v StateClue) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol12(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v StateClue) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol12(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *StateClue) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol12(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *StateClue) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol12(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol13(in *jlexer.Lexer, out *State) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "playerID":
+			out.PlayerID = string(in.String())
+		case "token":
+			out.Token = string(in.String())
+		case "spectator":
+			out.Spectator = bool(in.Bool())
+		case "roomState":
+			if in.IsNull() {
+				in.Skip()
+				out.RoomState = nil
+			} else {
+				if out.RoomState == nil {
+					out.RoomState = new(RoomState)
+				}
+				(*out.RoomState).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol13(out *jwriter.Writer, in State) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"playerID\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.PlayerID))
+	}
+	{
+		const prefix string = ",\"token\":"
+		out.RawString(prefix)
+		out.String(string(in.Token))
+	}
+	{
+		const prefix string = ",\"spectator\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Spectator))
+	}
+	{
+		const prefix string = ",\"roomState\":"
+		out.RawString(prefix)
+		if in.RoomState == nil {
+			out.RawString("null")
+		} else {
+			(*in.RoomState).MarshalEasyJSON(out)
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v State) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol13(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v State) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol13(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *State) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol13(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *State) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol13(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol14(in *jlexer.Lexer, out *ServerNote) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "method":
+			out.Method = ServerMethod(in.String())
+		case "params":
+			if m, ok := out.Params.(easyjson.Unmarshaler); ok {
+				m.UnmarshalEasyJSON(in)
+			} else if m, ok := out.Params.(json.Unmarshaler); ok {
+				_ = m.UnmarshalJSON(in.Raw())
+			} else {
+				out.Params = in.Interface()
+			}
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol14(out *jwriter.Writer, in ServerNote) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"method\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Method))
+	}
+	{
+		const prefix string = ",\"params\":"
+		out.RawString(prefix)
+		if m, ok := in.Params.(easyjson.Marshaler); ok {
+			m.MarshalEasyJSON(out)
+		} else if m, ok := in.Params.(json.Marshaler); ok {
+			out.Raw(m.MarshalJSON())
+		} else {
+			out.Raw(json.Marshal(in.Params))
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ServerNote) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol14(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ServerNote) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol14(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ServerNote) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol14(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ServerNote) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol14(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol15(in *jlexer.Lexer, out *RoomsResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "rooms":
+			if in.IsNull() {
+				in.Skip()
+				out.Rooms = nil
+			} else {
+				in.Delim('[')
+				if out.Rooms == nil {
+					if !in.IsDelim(']') {
+						out.Rooms = make([]*LobbyRoom, 0, 8)
+					} else {
+						out.Rooms = []*LobbyRoom{}
+					}
+				} else {
+					out.Rooms = (out.Rooms)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v19 *LobbyRoom
+					if in.IsNull() {
+						in.Skip()
+						v19 = nil
+					} else {
+						if v19 == nil {
+							v19 = new(LobbyRoom)
+						}
+						(*v19).UnmarshalEasyJSON(in)
+					}
+					out.Rooms = append(out.Rooms, v19)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "total":
+			out.Total = int(in.Int())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol15(out *jwriter.Writer, in RoomsResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"rooms\":"
+		out.RawString(prefix[1:])
+		if in.Rooms == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v20, v21 := range in.Rooms {
+				if v20 > 0 {
+					out.RawByte(',')
+				}
+				if v21 == nil {
+					out.RawString("null")
+				} else {
+					(*v21).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"total\":"
+		out.RawString(prefix)
+		out.Int(int(in.Total))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RoomsResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol15(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RoomsResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol15(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RoomsResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol15(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RoomsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol15(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol16(in *jlexer.Lexer, out *RoomState) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "mode":
+			out.Mode = game.Mode(in.String())
+		case "version":
+			out.Version = int(in.Int())
+		case "teams":
+			if in.IsNull() {
+				in.Skip()
+				out.Teams = nil
+			} else {
+				in.Delim('[')
+				if out.Teams == nil {
+					if !in.IsDelim(']') {
+						out.Teams = make([][]*StatePlayer, 0, 2)
+					} else {
+						out.Teams = [][]*StatePlayer{}
+					}
+				} else {
+					out.Teams = (out.Teams)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v22 []*StatePlayer
+					if in.IsNull() {
+						in.Skip()
+						v22 = nil
+					} else {
+						in.Delim('[')
+						if v22 == nil {
+							if !in.IsDelim(']') {
+								v22 = make([]*StatePlayer, 0, 8)
+							} else {
+								v22 = []*StatePlayer{}
+							}
+						} else {
+							v22 = (v22)[:0]
+						}
+						for !in.IsDelim(']') {
+							var v23 *StatePlayer
+							if in.IsNull() {
+								in.Skip()
+								v23 = nil
+							} else {
+								if v23 == nil {
+									v23 = new(StatePlayer)
+								}
+								(*v23).UnmarshalEasyJSON(in)
+							}
+							v22 = append(v22, v23)
+							in.WantComma()
+						}
+						in.Delim(']')
+					}
+					out.Teams = append(out.Teams, v22)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "numTeams":
+			out.NumTeams = int(in.Int())
+		case "turn":
+			out.Turn = game.Team(in.Int())
+		case "winner":
+			if in.IsNull() {
+				in.Skip()
+				out.Winner = nil
+			} else {
+				if out.Winner == nil {
+					out.Winner = new(game.Team)
+				}
+				*out.Winner = game.Team(in.Int())
+			}
+		case "eliminated":
+			if in.IsNull() {
+				in.Skip()
+				out.Eliminated = nil
+			} else {
+				in.Delim('[')
+				if out.Eliminated == nil {
+					if !in.IsDelim(']') {
+						out.Eliminated = make([]bool, 0, 64)
+					} else {
+						out.Eliminated = []bool{}
+					}
+				} else {
+					out.Eliminated = (out.Eliminated)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v24 bool
+					v24 = bool(in.Bool())
+					out.Eliminated = append(out.Eliminated, v24)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "duet":
+			if in.IsNull() {
+				in.Skip()
+				out.Duet = nil
+			} else {
+				if out.Duet == nil {
+					out.Duet = new(StateDuet)
+				}
+				(*out.Duet).UnmarshalEasyJSON(in)
+			}
+		case "clue":
+			if in.IsNull() {
+				in.Skip()
+				out.Clue = nil
+			} else {
+				if out.Clue == nil {
+					out.Clue = new(StateClue)
+				}
+				(*out.Clue).UnmarshalEasyJSON(in)
+			}
+		case "board":
+			if in.IsNull() {
+				in.Skip()
+				out.Board = nil
+			} else {
+				in.Delim('[')
+				if out.Board == nil {
+					if !in.IsDelim(']') {
+						out.Board = make([][]*StateTile, 0, 2)
+					} else {
+						out.Board = [][]*StateTile{}
+					}
+				} else {
+					out.Board = (out.Board)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v25 []*StateTile
+					if in.IsNull() {
+						in.Skip()
+						v25 = nil
+					} else {
+						in.Delim('[')
+						if v25 == nil {
+							if !in.IsDelim(']') {
+								v25 = make([]*StateTile, 0, 8)
+							} else {
+								v25 = []*StateTile{}
+							}
+						} else {
+							v25 = (v25)[:0]
+						}
+						for !in.IsDelim(']') {
+							var v26 *StateTile
+							if in.IsNull() {
+								in.Skip()
+								v26 = nil
+							} else {
+								if v26 == nil {
+									v26 = new(StateTile)
+								}
+								(*v26).UnmarshalEasyJSON(in)
+							}
+							v25 = append(v25, v26)
+							in.WantComma()
+						}
+						in.Delim(']')
+					}
+					out.Board = append(out.Board, v25)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "wordsLeft":
+			if in.IsNull() {
+				in.Skip()
+				out.WordsLeft = nil
+			} else {
+				in.Delim('[')
+				if out.WordsLeft == nil {
+					if !in.IsDelim(']') {
+						out.WordsLeft = make([]int, 0, 8)
+					} else {
+						out.WordsLeft = []int{}
+					}
+				} else {
+					out.WordsLeft = (out.WordsLeft)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v27 int
+					v27 = int(in.Int())
+					out.WordsLeft = append(out.WordsLeft, v27)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "lists":
+			if in.IsNull() {
+				in.Skip()
+				out.Lists = nil
+			} else {
+				in.Delim('[')
+				if out.Lists == nil {
+					if !in.IsDelim(']') {
+						out.Lists = make([]*StateWordList, 0, 8)
+					} else {
+						out.Lists = []*StateWordList{}
+					}
+				} else {
+					out.Lists = (out.Lists)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v28 *StateWordList
+					if in.IsNull() {
+						in.Skip()
+						v28 = nil
+					} else {
+						if v28 == nil {
+							v28 = new(StateWordList)
+						}
+						(*v28).UnmarshalEasyJSON(in)
+					}
+					out.Lists = append(out.Lists, v28)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "log":
+			if in.IsNull() {
+				in.Skip()
+				out.Log = nil
+			} else {
+				in.Delim('[')
+				if out.Log == nil {
+					if !in.IsDelim(']') {
+						out.Log = make([]*StateEvent, 0, 8)
+					} else {
+						out.Log = []*StateEvent{}
+					}
+				} else {
+					out.Log = (out.Log)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v29 *StateEvent
+					if in.IsNull() {
+						in.Skip()
+						v29 = nil
+					} else {
+						if v29 == nil {
+							v29 = new(StateEvent)
+						}
+						(*v29).UnmarshalEasyJSON(in)
+					}
+					out.Log = append(out.Log, v29)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "seed":
+			if in.IsNull() {
+				in.Skip()
+				out.Seed = nil
+			} else {
+				if out.Seed == nil {
+					out.Seed = new(string)
+				}
+				*out.Seed = string(in.String())
+			}
+		case "undo":
+			if in.IsNull() {
+				in.Skip()
+				out.Undo = nil
+			} else {
+				if out.Undo == nil {
+					out.Undo = new(StateUndo)
+				}
+				(*out.Undo).UnmarshalEasyJSON(in)
+			}
+		case "undoRule":
+			out.UndoRule = game.UndoRule(in.String())
+		case "revealShare":
+			out.RevealShare = int(in.Int())
+		case "timer":
+			if in.IsNull() {
+				in.Skip()
+				out.Timer = nil
+			} else {
+				if out.Timer == nil {
+					out.Timer = new(StateTimer)
+				}
+				(*out.Timer).UnmarshalEasyJSON(in)
+			}
+		case "hideBomb":
+			out.HideBomb = bool(in.Bool())
+		case "rows":
+			out.Rows = int(in.Int())
+		case "cols":
+			out.Cols = int(in.Int())
+		case "host":
+			out.Host = string(in.String())
+		case "locked":
+			out.Locked = bool(in.Bool())
+		case "hostOnly":
+			out.HostOnly = bool(in.Bool())
+		case "streamSafe":
+			out.StreamSafe = bool(in.Bool())
+		case "spectators":
+			if in.IsNull() {
+				in.Skip()
+				out.Spectators = nil
+			} else {
+				in.Delim('[')
+				if out.Spectators == nil {
+					if !in.IsDelim(']') {
+						out.Spectators = make([]*StatePlayer, 0, 8)
+					} else {
+						out.Spectators = []*StatePlayer{}
+					}
+				} else {
+					out.Spectators = (out.Spectators)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v30 *StatePlayer
+					if in.IsNull() {
+						in.Skip()
+						v30 = nil
+					} else {
+						if v30 == nil {
+							v30 = new(StatePlayer)
+						}
+						(*v30).UnmarshalEasyJSON(in)
+					}
+					out.Spectators = append(out.Spectators, v30)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "hideTeamChat":
+			out.HideTeamChat = bool(in.Bool())
+		case "invites":
+			out.Invites = int(in.Int())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol16(out *jwriter.Writer, in RoomState) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"mode\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Mode))
+	}
+	{
+		const prefix string = ",\"version\":"
+		out.RawString(prefix)
+		out.Int(int(in.Version))
+	}
+	{
+		const prefix string = ",\"teams\":"
+		out.RawString(prefix)
+		if in.Teams == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v31, v32 := range in.Teams {
+				if v31 > 0 {
+					out.RawByte(',')
+				}
+				if v32 == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+					out.RawString("null")
+				} else {
+					out.RawByte('[')
+					for v33, v34 := range v32 {
+						if v33 > 0 {
+							out.RawByte(',')
+						}
+						if v34 == nil {
+							out.RawString("null")
+						} else {
+							(*v34).MarshalEasyJSON(out)
+						}
+					}
+					out.RawByte(']')
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"numTeams\":"
+		out.RawString(prefix)
+		out.Int(int(in.NumTeams))
+	}
+	{
+		const prefix string = ",\"turn\":"
+		out.RawString(prefix)
+		out.Int(int(in.Turn))
+	}
+	{
+		const prefix string = ",\"winner\":"
+		out.RawString(prefix)
+		if in.Winner == nil {
+			out.RawString("null")
+		} else {
+			out.Int(int(*in.Winner))
+		}
+	}
+	{
+		const prefix string = ",\"eliminated\":"
+		out.RawString(prefix)
+		if in.Eliminated == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v35, v36 := range in.Eliminated {
+				if v35 > 0 {
+					out.RawByte(',')
+				}
+				out.Bool(bool(v36))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"duet\":"
+		out.RawString(prefix)
+		if in.Duet == nil {
+			out.RawString("null")
+		} else {
+			(*in.Duet).MarshalEasyJSON(out)
+		}
+	}
+	{
+		const prefix string = ",\"clue\":"
+		out.RawString(prefix)
+		if in.Clue == nil {
+			out.RawString("null")
+		} else {
+			(*in.Clue).MarshalEasyJSON(out)
+		}
+	}
+	{
+		const prefix string = ",\"board\":"
+		out.RawString(prefix)
+		if in.Board == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v37, v38 := range in.Board {
+				if v37 > 0 {
+					out.RawByte(',')
+				}
+				if v38 == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+					out.RawString("null")
+				} else {
+					out.RawByte('[')
+					for v39, v40 := range v38 {
+						if v39 > 0 {
+							out.RawByte(',')
+						}
+						if v40 == nil {
+							out.RawString("null")
+						} else {
+							(*v40).MarshalEasyJSON(out)
+						}
+					}
+					out.RawByte(']')
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"wordsLeft\":"
+		out.RawString(prefix)
+		if in.WordsLeft == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v41, v42 := range in.WordsLeft {
+				if v41 > 0 {
+					out.RawByte(',')
+				}
+				out.Int(int(v42))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"lists\":"
+		out.RawString(prefix)
+		if in.Lists == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v43, v44 := range in.Lists {
+				if v43 > 0 {
+					out.RawByte(',')
+				}
+				if v44 == nil {
+					out.RawString("null")
+				} else {
+					(*v44).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"log\":"
+		out.RawString(prefix)
+		if in.Log == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v45, v46 := range in.Log {
+				if v45 > 0 {
+					out.RawByte(',')
+				}
+				if v46 == nil {
+					out.RawString("null")
+				} else {
+					(*v46).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"seed\":"
+		out.RawString(prefix)
+		if in.Seed == nil {
+			out.RawString("null")
+		} else {
+			out.String(string(*in.Seed))
+		}
+	}
+	{
+		const prefix string = ",\"undo\":"
+		out.RawString(prefix)
+		if in.Undo == nil {
+			out.RawString("null")
+		} else {
+			(*in.Undo).MarshalEasyJSON(out)
+		}
+	}
+	{
+		const prefix string = ",\"undoRule\":"
+		out.RawString(prefix)
+		out.String(string(in.UndoRule))
+	}
+	{
+		const prefix string = ",\"revealShare\":"
+		out.RawString(prefix)
+		out.Int(int(in.RevealShare))
+	}
+	{
+		const prefix string = ",\"timer\":"
+		out.RawString(prefix)
+		if in.Timer == nil {
+			out.RawString("null")
+		} else {
+			(*in.Timer).MarshalEasyJSON(out)
+		}
+	}
+	{
+		const prefix string = ",\"hideBomb\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.HideBomb))
+	}
+	{
+		const prefix string = ",\"rows\":"
+		out.RawString(prefix)
+		out.Int(int(in.Rows))
+	}
+	{
+		const prefix string = ",\"cols\":"
+		out.RawString(prefix)
+		out.Int(int(in.Cols))
+	}
+	{
+		const prefix string = ",\"host\":"
+		out.RawString(prefix)
+		out.String(string(in.Host))
+	}
+	{
+		const prefix string = ",\"locked\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Locked))
+	}
+	{
+		const prefix string = ",\"hostOnly\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.HostOnly))
+	}
+	{
+		const prefix string = ",\"streamSafe\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.StreamSafe))
+	}
+	{
+		const prefix string = ",\"spectators\":"
+		out.RawString(prefix)
+		if in.Spectators == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v47, v48 := range in.Spectators {
+				if v47 > 0 {
+					out.RawByte(',')
+				}
+				if v48 == nil {
+					out.RawString("null")
+				} else {
+					(*v48).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"hideTeamChat\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.HideTeamChat))
+	}
+	{
+		const prefix string = ",\"invites\":"
+		out.RawString(prefix)
+		out.Int(int(in.Invites))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RoomState) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol16(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RoomState) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol16(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RoomState) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol16(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RoomState) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol16(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol17(in *jlexer.Lexer, out *RoomResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			if in.IsNull() {
+				in.Skip()
+				out.ID = nil
+			} else {
+				if out.ID == nil {
+					out.ID = new(string)
+				}
+				*out.ID = string(in.String())
+			}
+		case "key":
+			if in.IsNull() {
+				in.Skip()
+				out.Key = nil
+			} else {
+				if out.Key == nil {
+					out.Key = new(string)
+				}
+				*out.Key = string(in.String())
+			}
+		case "error":
+			if in.IsNull() {
+				in.Skip()
+				out.Error = nil
+			} else {
+				if out.Error == nil {
+					out.Error = new(string)
+				}
+				*out.Error = string(in.String())
+			}
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol17(out *jwriter.Writer, in RoomResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.ID != nil {
+		const prefix string = ",\"id\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(*in.ID))
+	}
+	if in.Key != nil {
+		const prefix string = ",\"key\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(*in.Key))
+	}
+	if in.Error != nil {
+		const prefix string = ",\"error\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(*in.Error))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RoomResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol17(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RoomResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol17(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RoomResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol17(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RoomResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol17(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol18(in *jlexer.Lexer, out *RoomRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "roomName":
+			out.RoomName = string(in.String())
+		case "roomPass":
+			out.RoomPass = string(in.String())
+		case "create":
+			out.Create = bool(in.Bool())
+		case "public":
+			out.Public = bool(in.Bool())
+		case "invite":
+			out.Invite = string(in.String())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol18(out *jwriter.Writer, in RoomRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"roomName\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.RoomName))
+	}
+	{
+		const prefix string = ",\"roomPass\":"
+		out.RawString(prefix)
+		out.String(string(in.RoomPass))
+	}
+	{
+		const prefix string = ",\"create\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Create))
+	}
+	if in.Public {
+		const prefix string = ",\"public\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Public))
+	}
+	if in.Invite != "" {
+		const prefix string = ",\"invite\":"
+		out.RawString(prefix)
+		out.String(string(in.Invite))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RoomRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol18(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RoomRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol18(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RoomRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol18(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RoomRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol18(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol19(in *jlexer.Lexer, out *RevokeInvitesParams) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol19(out *jwriter.Writer, in RevokeInvitesParams) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RevokeInvitesParams) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol19(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RevokeInvitesParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol19(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RevokeInvitesParams) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol19(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RevokeInvitesParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol19(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol20(in *jlexer.Lexer, out *RevealParams) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "row":
+			out.Row = int(in.Int())
+		case "col":
+			out.Col = int(in.Int())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol20(out *jwriter.Writer, in RevealParams) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"row\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Row))
+	}
+	{
+		const prefix string = ",\"col\":"
+		out.RawString(prefix)
+		out.Int(int(in.Col))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RevealParams) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol20(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RevealParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol20(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RevealParams) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol20(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RevealParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol20(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol21(in *jlexer.Lexer, out *Result) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int64(in.Int64())
+		case "ok":
+			out.OK = bool(in.Bool())
+		case "code":
+			out.Code = ResultCode(in.String())
+		case "message":
+			out.Message = string(in.String())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol21(out *jwriter.Writer, in Result) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.ID))
+	}
+	{
+		const prefix string = ",\"ok\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.OK))
+	}
+	if in.Code != "" {
+		const prefix string = ",\"code\":"
+		out.RawString(prefix)
+		out.String(string(in.Code))
+	}
+	if in.Message != "" {
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Result) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol21(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Result) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol21(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Result) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol21(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Result) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol21(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol22(in *jlexer.Lexer, out *RemovePackParams) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "num":
+			out.Num = int(in.Int())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol22(out *jwriter.Writer, in RemovePackParams) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"num\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Num))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RemovePackParams) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol22(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RemovePackParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol22(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RemovePackParams) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol22(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RemovePackParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol22(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol23(in *jlexer.Lexer, out *RandomizeTeamsParams) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol23(out *jwriter.Writer, in RandomizeTeamsParams) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RandomizeTeamsParams) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol23(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RandomizeTeamsParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol23(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RandomizeTeamsParams) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol23(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RandomizeTeamsParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol23(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol24(in *jlexer.Lexer, out *PatchOp) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "op":
+			out.Op = string(in.String())
+		case "path":
+			out.Path = string(in.String())
+		case "value":
+			if m, ok := out.Value.(easyjson.Unmarshaler); ok {
+				m.UnmarshalEasyJSON(in)
+			} else if m, ok := out.Value.(json.Unmarshaler); ok {
+				_ = m.UnmarshalJSON(in.Raw())
+			} else {
+				out.Value = in.Interface()
+			}
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol24(out *jwriter.Writer, in PatchOp) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"op\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Op))
+	}
+	{
+		const prefix string = ",\"path\":"
+		out.RawString(prefix)
+		out.String(string(in.Path))
+	}
+	{
+		const prefix string = ",\"value\":"
+		out.RawString(prefix)
+		if m, ok := in.Value.(easyjson.Marshaler); ok {
+			m.MarshalEasyJSON(out)
+		} else if m, ok := in.Value.(json.Marshaler); ok {
+			out.Raw(m.MarshalJSON())
+		} else {
+			out.Raw(json.Marshal(in.Value))
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PatchOp) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol24(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PatchOp) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol24(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PatchOp) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol24(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PatchOp) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol24(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol25(in *jlexer.Lexer, out *Patch) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "from":
+			out.From = int(in.Int())
+		case "to":
+			out.To = int(in.Int())
+		case "ops":
+			if in.IsNull() {
+				in.Skip()
+				out.Ops = nil
+			} else {
+				in.Delim('[')
+				if out.Ops == nil {
+					if !in.IsDelim(']') {
+						out.Ops = make([]*PatchOp, 0, 8)
+					} else {
+						out.Ops = []*PatchOp{}
+					}
+				} else {
+					out.Ops = (out.Ops)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v49 *PatchOp
+					if in.IsNull() {
+						in.Skip()
+						v49 = nil
+					} else {
+						if v49 == nil {
+							v49 = new(PatchOp)
+						}
+						(*v49).UnmarshalEasyJSON(in)
+					}
+					out.Ops = append(out.Ops, v49)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol25(out *jwriter.Writer, in Patch) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"from\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.From))
+	}
+	{
+		const prefix string = ",\"to\":"
+		out.RawString(prefix)
+		out.Int(int(in.To))
+	}
+	{
+		const prefix string = ",\"ops\":"
+		out.RawString(prefix)
+		if in.Ops == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v50, v51 := range in.Ops {
+				if v50 > 0 {
+					out.RawByte(',')
+				}
+				if v51 == nil {
+					out.RawString("null")
+				} else {
+					(*v51).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Patch) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol25(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Patch) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol25(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Patch) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol25(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Patch) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol25(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol26(in *jlexer.Lexer, out *NewGameParams) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "seed":
+			if in.IsNull() {
+				in.Skip()
+				out.Seed = nil
+			} else {
+				if out.Seed == nil {
+					out.Seed = new(string)
+				}
+				*out.Seed = string(in.String())
+			}
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol26(out *jwriter.Writer, in NewGameParams) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Seed != nil {
+		const prefix string = ",\"seed\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(*in.Seed))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v NewGameParams) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol26(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v NewGameParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol26(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *NewGameParams) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol26(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *NewGameParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol26(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol27(in *jlexer.Lexer, out *LobbyRoom) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "players":
+			out.Players = int(in.Int())
+		case "teams":
+			if in.IsNull() {
+				in.Skip()
+				out.Teams = nil
+			} else {
+				in.Delim('[')
+				if out.Teams == nil {
+					if !in.IsDelim(']') {
+						out.Teams = make([]int, 0, 8)
+					} else {
+						out.Teams = []int{}
+					}
+				} else {
+					out.Teams = (out.Teams)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v52 int
+					v52 = int(in.Int())
+					out.Teams = append(out.Teams, v52)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "spectators":
+			out.Spectators = int(in.Int())
+		case "mode":
+			out.Mode = game.Mode(in.String())
+		case "inProgress":
+			out.InProgress = bool(in.Bool())
+		case "packs":
+			if in.IsNull() {
+				in.Skip()
+				out.Packs = nil
+			} else {
+				in.Delim('[')
+				if out.Packs == nil {
+					if !in.IsDelim(']') {
+						out.Packs = make([]string, 0, 4)
+					} else {
+						out.Packs = []string{}
+					}
+				} else {
+					out.Packs = (out.Packs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v53 string
+					v53 = string(in.String())
+					out.Packs = append(out.Packs, v53)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol27(out *jwriter.Writer, in LobbyRoom) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"players\":"
+		out.RawString(prefix)
+		out.Int(int(in.Players))
+	}
+	{
+		const prefix string = ",\"teams\":"
+		out.RawString(prefix)
+		if in.Teams == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v54, v55 := range in.Teams {
+				if v54 > 0 {
+					out.RawByte(',')
+				}
+				out.Int(int(v55))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"spectators\":"
+		out.RawString(prefix)
+		out.Int(int(in.Spectators))
+	}
+	{
+		const prefix string = ",\"mode\":"
+		out.RawString(prefix)
+		out.String(string(in.Mode))
+	}
+	{
+		const prefix string = ",\"inProgress\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.InProgress))
+	}
+	{
+		const prefix string = ",\"packs\":"
+		out.RawString(prefix)
+		if in.Packs == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v56, v57 := range in.Packs {
+				if v56 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v57))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LobbyRoom) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol27(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LobbyRoom) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol27(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LobbyRoom) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol27(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LobbyRoom) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol27(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol28(in *jlexer.Lexer, out *KickParams) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "playerID":
+			out.PlayerID = string(in.String())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol28(out *jwriter.Writer, in KickParams) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"playerID\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.PlayerID))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v KickParams) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol28(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v KickParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol28(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *KickParams) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol28(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *KickParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol28(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol29(in *jlexer.Lexer, out *InviteResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "token":
+			out.Token = string(in.String())
+		case "expires":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Expires).UnmarshalJSON(data))
+			}
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol29(out *jwriter.Writer, in InviteResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"token\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Token))
+	}
+	{
+		const prefix string = ",\"expires\":"
+		out.RawString(prefix)
+		out.Raw((in.Expires).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v InviteResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol29(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v InviteResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol29(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *InviteResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol29(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *InviteResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol29(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol30(in *jlexer.Lexer, out *InviteRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "expires":
+			out.Expires = int(in.Int())
+		case "singleUse":
+			out.SingleUse = bool(in.Bool())
+		case "team":
+			if in.IsNull() {
+				in.Skip()
+				out.Team = nil
+			} else {
+				if out.Team == nil {
+					out.Team = new(game.Team)
+				}
+				*out.Team = game.Team(in.Int())
+			}
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol30(out *jwriter.Writer, in InviteRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Expires != 0 {
+		const prefix string = ",\"expires\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Int(int(in.Expires))
+	}
+	if in.SingleUse {
+		const prefix string = ",\"singleUse\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.SingleUse))
+	}
+	if in.Team != nil {
+		const prefix string = ",\"team\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(*in.Team))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v InviteRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol30(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v InviteRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol30(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *InviteRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol30(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *InviteRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol30(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol31(in *jlexer.Lexer, out *GiveClueParams) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "word":
+			out.Word = string(in.String())
+		case "count":
+			out.Count = int(in.Int())
+		case "unlimited":
+			out.Unlimited = bool(in.Bool())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol31(out *jwriter.Writer, in GiveClueParams) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"word\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Word))
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Int(int(in.Count))
+	}
+	{
+		const prefix string = ",\"unlimited\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Unlimited))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v GiveClueParams) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol31(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v GiveClueParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol31(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *GiveClueParams) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol31(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *GiveClueParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol31(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol32(in *jlexer.Lexer, out *EndTurnParams) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol32(out *jwriter.Writer, in EndTurnParams) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v EndTurnParams) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol32(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v EndTurnParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol32(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *EndTurnParams) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol32(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *EndTurnParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol32(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol33(in *jlexer.Lexer, out *CloseEvent) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "code":
+			out.Code = int(in.Int())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol33(out *jwriter.Writer, in CloseEvent) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"code\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Code))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CloseEvent) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol33(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CloseEvent) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol33(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CloseEvent) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol33(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CloseEvent) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol33(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol34(in *jlexer.Lexer, out *ClientNote) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			if in.IsNull() {
+				in.Skip()
+				out.ID = nil
+			} else {
+				if out.ID == nil {
+					out.ID = new(int64)
+				}
+				*out.ID = int64(in.Int64())
+			}
+		case "method":
+			out.Method = ClientMethod(in.StringIntern())
+		case "version":
+			out.Version = int(in.Int())
+		case "params":
+			(out.Params).UnmarshalEasyJSON(in)
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol34(out *jwriter.Writer, in ClientNote) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.ID != nil {
+		const prefix string = ",\"id\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Int64(int64(*in.ID))
+	}
+	{
+		const prefix string = ",\"method\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Method))
+	}
+	{
+		const prefix string = ",\"version\":"
+		out.RawString(prefix)
+		out.Int(int(in.Version))
+	}
+	{
+		const prefix string = ",\"params\":"
+		out.RawString(prefix)
+		(in.Params).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ClientNote) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol34(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ClientNote) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol34(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ClientNote) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol34(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ClientNote) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol34(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol35(in *jlexer.Lexer, out *ChatParams) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "text":
+			out.Text = string(in.String())
+		case "team":
+			out.Team = bool(in.Bool())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol35(out *jwriter.Writer, in ChatParams) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"text\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Text))
+	}
+	{
+		const prefix string = ",\"team\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Team))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChatParams) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol35(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChatParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol35(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChatParams) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol35(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChatParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol35(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol36(in *jlexer.Lexer, out *ChatMessage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int64(in.Int64())
+		case "playerID":
+			out.PlayerID = string(in.String())
+		case "nickname":
+			out.Nickname = string(in.String())
+		case "text":
+			out.Text = string(in.String())
+		case "team":
+			if in.IsNull() {
+				in.Skip()
+				out.Team = nil
+			} else {
+				if out.Team == nil {
+					out.Team = new(game.Team)
+				}
+				*out.Team = game.Team(in.Int())
+			}
+		case "time":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Time).UnmarshalJSON(data))
+			}
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol36(out *jwriter.Writer, in ChatMessage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.ID))
+	}
+	{
+		const prefix string = ",\"playerID\":"
+		out.RawString(prefix)
+		out.String(string(in.PlayerID))
+	}
+	{
+		const prefix string = ",\"nickname\":"
+		out.RawString(prefix)
+		out.String(string(in.Nickname))
+	}
+	{
+		const prefix string = ",\"text\":"
+		out.RawString(prefix)
+		out.String(string(in.Text))
+	}
+	{
+		const prefix string = ",\"team\":"
+		out.RawString(prefix)
+		if in.Team == nil {
+			out.RawString("null")
+		} else {
+			out.Int(int(*in.Team))
+		}
+	}
+	{
+		const prefix string = ",\"time\":"
+		out.RawString(prefix)
+		out.Raw((in.Time).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChatMessage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol36(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChatMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol36(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChatMessage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol36(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChatMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol36(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol37(in *jlexer.Lexer, out *Chat) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "messages":
+			if in.IsNull() {
+				in.Skip()
+				out.Messages = nil
+			} else {
+				in.Delim('[')
+				if out.Messages == nil {
+					if !in.IsDelim(']') {
+						out.Messages = make([]*ChatMessage, 0, 8)
+					} else {
+						out.Messages = []*ChatMessage{}
+					}
+				} else {
+					out.Messages = (out.Messages)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v58 *ChatMessage
+					if in.IsNull() {
+						in.Skip()
+						v58 = nil
+					} else {
+						if v58 == nil {
+							v58 = new(ChatMessage)
+						}
+						(*v58).UnmarshalEasyJSON(in)
+					}
+					out.Messages = append(out.Messages, v58)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol37(out *jwriter.Writer, in Chat) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"messages\":"
+		out.RawString(prefix[1:])
+		if in.Messages == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v59, v60 := range in.Messages {
+				if v59 > 0 {
+					out.RawByte(',')
+				}
+				if v60 == nil {
+					out.RawString("null")
+				} else {
+					(*v60).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Chat) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol37(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Chat) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol37(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Chat) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol37(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Chat) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol37(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol38(in *jlexer.Lexer, out *ChangeUndoRuleParams) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "rule":
+			out.Rule = game.UndoRule(in.String())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol38(out *jwriter.Writer, in ChangeUndoRuleParams) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"rule\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Rule))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChangeUndoRuleParams) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol38(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChangeUndoRuleParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol38(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChangeUndoRuleParams) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol38(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChangeUndoRuleParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol38(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol39(in *jlexer.Lexer, out *ChangeTurnTimeParams) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "seconds":
+			out.Seconds = int(in.Int())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol39(out *jwriter.Writer, in ChangeTurnTimeParams) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"seconds\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Seconds))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChangeTurnTimeParams) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol39(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChangeTurnTimeParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol39(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChangeTurnTimeParams) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol39(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChangeTurnTimeParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol39(l, v)
+}
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol40(in *jlexer.Lexer, out *ChangeTurnModeParams) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "timed":
+			out.Timed = bool(in.Bool())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol40(out *jwriter.Writer, in ChangeTurnModeParams) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"version\":"
+		const prefix string = ",\"timed\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.Version))
-	}
-	{
-		const prefix string = ",\"teams\":"
-		out.RawString(prefix)
-		if in.Teams == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v7, v8 := range in.Teams {
-				if v7 > 0 {
-					out.RawByte(',')
-				}
-				if v8 == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-					out.RawString("null")
-				} else {
-					out.RawByte('[')
-					for v9, v10 := range v8 {
-						if v9 > 0 {
-							out.RawByte(',')
-						}
-						if v10 == nil {
-							out.RawString("null")
-						} else {
-							(*v10).MarshalEasyJSON(out)
-						}
-					}
-					out.RawByte(']')
-				}
-			}
-			out.RawByte(']')
-		}
-	}
-	{
-		const prefix string = ",\"turn\":"
-		out.RawString(prefix)
-		out.Int(int(in.Turn))
-	}
-	{
-		const prefix string = ",\"winner\":"
-		out.RawString(prefix)
-		if in.Winner == nil {
-			out.RawString("null")
-		} else {
-			out.Int(int(*in.Winner))
-		}
-	}
-	{
-		const prefix string = ",\"board\":"
-		out.RawString(prefix)
-		if in.Board == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v11, v12 := range in.Board {
-				if v11 > 0 {
-					out.RawByte(',')
-				}
-				if v12 == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-					out.RawString("null")
-				} else {
-					out.RawByte('[')
-					for v13, v14 := range v12 {
-						if v13 > 0 {
-							out.RawByte(',')
-						}
-						if v14 == nil {
-							out.RawString("null")
-						} else {
-							(*v14).MarshalEasyJSON(out)
-						}
-					}
-					out.RawByte(']')
-				}
-			}
-			out.RawByte(']')
-		}
-	}
-	{
-		const prefix string = ",\"wordsLeft\":"
-		out.RawString(prefix)
-		if in.WordsLeft == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v15, v16 := range in.WordsLeft {
-				if v15 > 0 {
-					out.RawByte(',')
-				}
-				out.Int(int(v16))
-			}
-			out.RawByte(']')
-		}
-	}
-	{
-		const prefix string = ",\"lists\":"
-		out.RawString(prefix)
-		if in.Lists == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v17, v18 := range in.Lists {
-				if v17 > 0 {
-					out.RawByte(',')
-				}
-				if v18 == nil {
-					out.RawString("null")
-				} else {
-					(*v18).MarshalEasyJSON(out)
-				}
-			}
-			out.RawByte(']')
-		}
-	}
-	{
-		const prefix string = ",\"timer\":"
-		out.RawString(prefix)
-		if in.Timer == nil {
-			out.RawString("null")
-		} else {
-			(*in.Timer).MarshalEasyJSON(out)
-		}
-	}
-	{
-		const prefix string = ",\"hideBomb\":"
-		out.RawString(prefix)
-		out.Bool(bool(in.HideBomb))
+		out.Bool(bool(in.Timed))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v RoomState) MarshalJSON() ([]byte, error) {
+func (v ChangeTurnModeParams) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol9(&w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol40(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v RoomState) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol9(w, v)
+func (v ChangeTurnModeParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol40(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *RoomState) UnmarshalJSON(data []byte) error {
+func (v *ChangeTurnModeParams) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol9(&r, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol40(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *RoomState) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol9(l, v)
+func (v *ChangeTurnModeParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol40(l, v)
 }
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol10(in *jlexer.Lexer, out *RoomResponse) {
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol41(in *jlexer.Lexer, out *ChangeTeamParams) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1176,26 +4623,8 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol10(in *jlexer.
 			continue
 		}
 		switch key {
-		case "id":
-			if in.IsNull() {
-				in.Skip()
-				out.ID = nil
-			} else {
-				if out.ID == nil {
-					out.ID = new(string)
-				}
-				*out.ID = string(in.String())
-			}
-		case "error":
-			if in.IsNull() {
-				in.Skip()
-				out.Error = nil
-			} else {
-				if out.Error == nil {
-					out.Error = new(string)
-				}
-				*out.Error = string(in.String())
-			}
+		case "team":
+			out.Team = game.Team(in.Int())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -1210,53 +4639,42 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol10(in *jlexer.
 		in.Consumed()
 	}
 }
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol10(out *jwriter.Writer, in RoomResponse) {
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol41(out *jwriter.Writer, in ChangeTeamParams) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	if in.ID != nil {
-		const prefix string = ",\"id\":"
-		first = false
+	{
+		const prefix string = ",\"team\":"
 		out.RawString(prefix[1:])
-		out.String(string(*in.ID))
-	}
-	if in.Error != nil {
-		const prefix string = ",\"error\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.String(string(*in.Error))
+		out.Int(int(in.Team))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v RoomResponse) MarshalJSON() ([]byte, error) {
+func (v ChangeTeamParams) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol10(&w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol41(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v RoomResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol10(w, v)
+func (v ChangeTeamParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol41(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *RoomResponse) UnmarshalJSON(data []byte) error {
+func (v *ChangeTeamParams) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol10(&r, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol41(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *RoomResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol10(l, v)
+func (v *ChangeTeamParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol41(l, v)
 }
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol11(in *jlexer.Lexer, out *RoomRequest) {
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol42(in *jlexer.Lexer, out *ChangeStreamSafeParams) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1275,12 +4693,8 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol11(in *jlexer.
 			continue
 		}
 		switch key {
-		case "roomName":
-			out.RoomName = string(in.String())
-		case "roomPass":
-			out.RoomPass = string(in.String())
-		case "create":
-			out.Create = bool(in.Bool())
+		case "streamSafe":
+			out.StreamSafe = bool(in.Bool())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -1295,52 +4709,42 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol11(in *jlexer.
 		in.Consumed()
 	}
 }
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol11(out *jwriter.Writer, in RoomRequest) {
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol42(out *jwriter.Writer, in ChangeStreamSafeParams) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"roomName\":"
+		const prefix string = ",\"streamSafe\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.RoomName))
-	}
-	{
-		const prefix string = ",\"roomPass\":"
-		out.RawString(prefix)
-		out.String(string(in.RoomPass))
-	}
-	{
-		const prefix string = ",\"create\":"
-		out.RawString(prefix)
-		out.Bool(bool(in.Create))
+		out.Bool(bool(in.StreamSafe))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v RoomRequest) MarshalJSON() ([]byte, error) {
+func (v ChangeStreamSafeParams) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol11(&w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol42(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v RoomRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol11(w, v)
+func (v ChangeStreamSafeParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol42(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *RoomRequest) UnmarshalJSON(data []byte) error {
+func (v *ChangeStreamSafeParams) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol11(&r, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol42(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *RoomRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol11(l, v)
+func (v *ChangeStreamSafeParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol42(l, v)
 }
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol12(in *jlexer.Lexer, out *RevealParams) {
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol43(in *jlexer.Lexer, out *ChangeRoleParams) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1359,10 +4763,10 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol12(in *jlexer.
 			continue
 		}
 		switch key {
-		case "row":
-			out.Row = int(in.Int())
-		case "col":
-			out.Col = int(in.Int())
+		case "spymaster":
+			out.Spymaster = bool(in.Bool())
+		case "spectator":
+			out.Spectator = bool(in.Bool())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -1377,47 +4781,47 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol12(in *jlexer.
 		in.Consumed()
 	}
 }
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol12(out *jwriter.Writer, in RevealParams) {
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol43(out *jwriter.Writer, in ChangeRoleParams) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"row\":"
+		const prefix string = ",\"spymaster\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.Row))
+		out.Bool(bool(in.Spymaster))
 	}
-	{
-		const prefix string = ",\"col\":"
+	if in.Spectator {
+		const prefix string = ",\"spectator\":"
 		out.RawString(prefix)
-		out.Int(int(in.Col))
+		out.Bool(bool(in.Spectator))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v RevealParams) MarshalJSON() ([]byte, error) {
+func (v ChangeRoleParams) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol12(&w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol43(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v RevealParams) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol12(w, v)
+func (v ChangeRoleParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol43(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *RevealParams) UnmarshalJSON(data []byte) error {
+func (v *ChangeRoleParams) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol12(&r, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol43(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *RevealParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol12(l, v)
+func (v *ChangeRoleParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol43(l, v)
 }
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol13(in *jlexer.Lexer, out *RemovePackParams) {
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol44(in *jlexer.Lexer, out *ChangeRevealShareParams) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1436,8 +4840,8 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol13(in *jlexer.
 			continue
 		}
 		switch key {
-		case "num":
-			out.Num = int(in.Int())
+		case "share":
+			out.Share = int(in.Int())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -1452,42 +4856,42 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol13(in *jlexer.
 		in.Consumed()
 	}
 }
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol13(out *jwriter.Writer, in RemovePackParams) {
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol44(out *jwriter.Writer, in ChangeRevealShareParams) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"num\":"
+		const prefix string = ",\"share\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.Num))
+		out.Int(int(in.Share))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v RemovePackParams) MarshalJSON() ([]byte, error) {
+func (v ChangeRevealShareParams) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol13(&w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol44(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v RemovePackParams) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol13(w, v)
+func (v ChangeRevealShareParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol44(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *RemovePackParams) UnmarshalJSON(data []byte) error {
+func (v *ChangeRevealShareParams) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol13(&r, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol44(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *RemovePackParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol13(l, v)
+func (v *ChangeRevealShareParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol44(l, v)
 }
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol14(in *jlexer.Lexer, out *RandomizeTeamsParams) {
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol45(in *jlexer.Lexer, out *ChangePackParams) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1506,6 +4910,10 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol14(in *jlexer.
 			continue
 		}
 		switch key {
+		case "num":
+			out.Num = int(in.Int())
+		case "enable":
+			out.Enable = bool(in.Bool())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -1520,37 +4928,47 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol14(in *jlexer.
 		in.Consumed()
 	}
 }
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol14(out *jwriter.Writer, in RandomizeTeamsParams) {
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol45(out *jwriter.Writer, in ChangePackParams) {
 	out.RawByte('{')
 	first := true
 	_ = first
+	{
+		const prefix string = ",\"num\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Num))
+	}
+	{
+		const prefix string = ",\"enable\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Enable))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v RandomizeTeamsParams) MarshalJSON() ([]byte, error) {
+func (v ChangePackParams) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol14(&w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol45(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v RandomizeTeamsParams) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol14(w, v)
+func (v ChangePackParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol45(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *RandomizeTeamsParams) UnmarshalJSON(data []byte) error {
+func (v *ChangePackParams) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol14(&r, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol45(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *RandomizeTeamsParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol14(l, v)
+func (v *ChangePackParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol45(l, v)
 }
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol15(in *jlexer.Lexer, out *NewGameParams) {
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol46(in *jlexer.Lexer, out *ChangeNumTeamsParams) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1569,6 +4987,8 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol15(in *jlexer.
 			continue
 		}
 		switch key {
+		case "numTeams":
+			out.NumTeams = int(in.Int())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -1583,37 +5003,42 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol15(in *jlexer.
 		in.Consumed()
 	}
 }
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol15(out *jwriter.Writer, in NewGameParams) {
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol46(out *jwriter.Writer, in ChangeNumTeamsParams) {
 	out.RawByte('{')
 	first := true
 	_ = first
+	{
+		const prefix string = ",\"numTeams\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.NumTeams))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v NewGameParams) MarshalJSON() ([]byte, error) {
+func (v ChangeNumTeamsParams) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol15(&w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol46(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v NewGameParams) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol15(w, v)
+func (v ChangeNumTeamsParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol46(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *NewGameParams) UnmarshalJSON(data []byte) error {
+func (v *ChangeNumTeamsParams) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol15(&r, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol46(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *NewGameParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol15(l, v)
+func (v *ChangeNumTeamsParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol46(l, v)
 }
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol16(in *jlexer.Lexer, out *EndTurnParams) {
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol47(in *jlexer.Lexer, out *ChangeNicknameParams) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1632,6 +5057,8 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol16(in *jlexer.
 			continue
 		}
 		switch key {
+		case "nickname":
+			out.Nickname = string(in.String())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -1646,37 +5073,42 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol16(in *jlexer.
 		in.Consumed()
 	}
 }
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol16(out *jwriter.Writer, in EndTurnParams) {
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol47(out *jwriter.Writer, in ChangeNicknameParams) {
 	out.RawByte('{')
 	first := true
 	_ = first
+	{
+		const prefix string = ",\"nickname\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Nickname))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v EndTurnParams) MarshalJSON() ([]byte, error) {
+func (v ChangeNicknameParams) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol16(&w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol47(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v EndTurnParams) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol16(w, v)
+func (v ChangeNicknameParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol47(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *EndTurnParams) UnmarshalJSON(data []byte) error {
+func (v *ChangeNicknameParams) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol16(&r, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol47(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *EndTurnParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol16(l, v)
+func (v *ChangeNicknameParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol47(l, v)
 }
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol17(in *jlexer.Lexer, out *ClientNote) {
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol48(in *jlexer.Lexer, out *ChangeModeParams) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1695,12 +5127,8 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol17(in *jlexer.
 			continue
 		}
 		switch key {
-		case "method":
-			out.Method = ClientMethod(in.StringIntern())
-		case "version":
-			out.Version = int(in.Int())
-		case "params":
-			(out.Params).UnmarshalEasyJSON(in)
+		case "mode":
+			out.Mode = game.Mode(in.String())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -1715,52 +5143,42 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol17(in *jlexer.
 		in.Consumed()
 	}
 }
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol17(out *jwriter.Writer, in ClientNote) {
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol48(out *jwriter.Writer, in ChangeModeParams) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"method\":"
+		const prefix string = ",\"mode\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Method))
-	}
-	{
-		const prefix string = ",\"version\":"
-		out.RawString(prefix)
-		out.Int(int(in.Version))
-	}
-	{
-		const prefix string = ",\"params\":"
-		out.RawString(prefix)
-		(in.Params).MarshalEasyJSON(out)
+		out.String(string(in.Mode))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ClientNote) MarshalJSON() ([]byte, error) {
+func (v ChangeModeParams) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol17(&w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol48(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ClientNote) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol17(w, v)
+func (v ChangeModeParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol48(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ClientNote) UnmarshalJSON(data []byte) error {
+func (v *ChangeModeParams) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol17(&r, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol48(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ClientNote) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol17(l, v)
+func (v *ChangeModeParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol48(l, v)
 }
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol18(in *jlexer.Lexer, out *ChangeTurnTimeParams) {
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol49(in *jlexer.Lexer, out *ChangeLockedParams) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1779,8 +5197,8 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol18(in *jlexer.
 			continue
 		}
 		switch key {
-		case "seconds":
-			out.Seconds = int(in.Int())
+		case "locked":
+			out.Locked = bool(in.Bool())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -1795,42 +5213,42 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol18(in *jlexer.
 		in.Consumed()
 	}
 }
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol18(out *jwriter.Writer, in ChangeTurnTimeParams) {
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol49(out *jwriter.Writer, in ChangeLockedParams) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"seconds\":"
+		const prefix string = ",\"locked\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.Seconds))
+		out.Bool(bool(in.Locked))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ChangeTurnTimeParams) MarshalJSON() ([]byte, error) {
+func (v ChangeLockedParams) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol18(&w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol49(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ChangeTurnTimeParams) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol18(w, v)
+func (v ChangeLockedParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol49(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ChangeTurnTimeParams) UnmarshalJSON(data []byte) error {
+func (v *ChangeLockedParams) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol18(&r, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol49(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ChangeTurnTimeParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol18(l, v)
+func (v *ChangeLockedParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol49(l, v)
 }
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol19(in *jlexer.Lexer, out *ChangeTurnModeParams) {
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol50(in *jlexer.Lexer, out *ChangeHostParams) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1849,8 +5267,8 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol19(in *jlexer.
 			continue
 		}
 		switch key {
-		case "timed":
-			out.Timed = bool(in.Bool())
+		case "playerID":
+			out.PlayerID = string(in.String())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -1865,42 +5283,42 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol19(in *jlexer.
 		in.Consumed()
 	}
 }
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol19(out *jwriter.Writer, in ChangeTurnModeParams) {
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol50(out *jwriter.Writer, in ChangeHostParams) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"timed\":"
+		const prefix string = ",\"playerID\":"
 		out.RawString(prefix[1:])
-		out.Bool(bool(in.Timed))
+		out.String(string(in.PlayerID))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ChangeTurnModeParams) MarshalJSON() ([]byte, error) {
+func (v ChangeHostParams) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol19(&w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol50(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ChangeTurnModeParams) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol19(w, v)
+func (v ChangeHostParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol50(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ChangeTurnModeParams) UnmarshalJSON(data []byte) error {
+func (v *ChangeHostParams) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol19(&r, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol50(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ChangeTurnModeParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol19(l, v)
+func (v *ChangeHostParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol50(l, v)
 }
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol20(in *jlexer.Lexer, out *ChangeTeamParams) {
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol51(in *jlexer.Lexer, out *ChangeHostOnlyParams) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1919,8 +5337,8 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol20(in *jlexer.
 			continue
 		}
 		switch key {
-		case "team":
-			out.Team = game.Team(in.Int())
+		case "hostOnly":
+			out.HostOnly = bool(in.Bool())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -1935,42 +5353,42 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol20(in *jlexer.
 		in.Consumed()
 	}
 }
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol20(out *jwriter.Writer, in ChangeTeamParams) {
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol51(out *jwriter.Writer, in ChangeHostOnlyParams) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"team\":"
+		const prefix string = ",\"hostOnly\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.Team))
+		out.Bool(bool(in.HostOnly))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ChangeTeamParams) MarshalJSON() ([]byte, error) {
+func (v ChangeHostOnlyParams) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol20(&w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol51(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ChangeTeamParams) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol20(w, v)
+func (v ChangeHostOnlyParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol51(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ChangeTeamParams) UnmarshalJSON(data []byte) error {
+func (v *ChangeHostOnlyParams) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol20(&r, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol51(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ChangeTeamParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol20(l, v)
+func (v *ChangeHostOnlyParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol51(l, v)
 }
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol21(in *jlexer.Lexer, out *ChangeRoleParams) {
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol52(in *jlexer.Lexer, out *ChangeHideTeamChatParams) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1989,8 +5407,8 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol21(in *jlexer.
 			continue
 		}
 		switch key {
-		case "spymaster":
-			out.Spymaster = bool(in.Bool())
+		case "hideTeamChat":
+			out.HideTeamChat = bool(in.Bool())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -2005,42 +5423,42 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol21(in *jlexer.
 		in.Consumed()
 	}
 }
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol21(out *jwriter.Writer, in ChangeRoleParams) {
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol52(out *jwriter.Writer, in ChangeHideTeamChatParams) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"spymaster\":"
+		const prefix string = ",\"hideTeamChat\":"
 		out.RawString(prefix[1:])
-		out.Bool(bool(in.Spymaster))
+		out.Bool(bool(in.HideTeamChat))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ChangeRoleParams) MarshalJSON() ([]byte, error) {
+func (v ChangeHideTeamChatParams) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol21(&w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol52(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ChangeRoleParams) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol21(w, v)
+func (v ChangeHideTeamChatParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol52(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ChangeRoleParams) UnmarshalJSON(data []byte) error {
+func (v *ChangeHideTeamChatParams) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol21(&r, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol52(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ChangeRoleParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol21(l, v)
+func (v *ChangeHideTeamChatParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol52(l, v)
 }
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol22(in *jlexer.Lexer, out *ChangePackParams) {
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol53(in *jlexer.Lexer, out *ChangeHideBombParams) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2059,10 +5477,8 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol22(in *jlexer.
 			continue
 		}
 		switch key {
-		case "num":
-			out.Num = int(in.Int())
-		case "enable":
-			out.Enable = bool(in.Bool())
+		case "hideBomb":
+			out.HideBomb = bool(in.Bool())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -2077,47 +5493,42 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol22(in *jlexer.
 		in.Consumed()
 	}
 }
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol22(out *jwriter.Writer, in ChangePackParams) {
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol53(out *jwriter.Writer, in ChangeHideBombParams) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"num\":"
+		const prefix string = ",\"hideBomb\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.Num))
-	}
-	{
-		const prefix string = ",\"enable\":"
-		out.RawString(prefix)
-		out.Bool(bool(in.Enable))
+		out.Bool(bool(in.HideBomb))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ChangePackParams) MarshalJSON() ([]byte, error) {
+func (v ChangeHideBombParams) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol22(&w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol53(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ChangePackParams) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol22(w, v)
+func (v ChangeHideBombParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol53(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ChangePackParams) UnmarshalJSON(data []byte) error {
+func (v *ChangeHideBombParams) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol22(&r, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol53(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ChangePackParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol22(l, v)
+func (v *ChangeHideBombParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol53(l, v)
 }
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol23(in *jlexer.Lexer, out *ChangeNicknameParams) {
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol54(in *jlexer.Lexer, out *ChangeBoardSizeParams) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2136,8 +5547,10 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol23(in *jlexer.
 			continue
 		}
 		switch key {
-		case "nickname":
-			out.Nickname = string(in.String())
+		case "rows":
+			out.Rows = int(in.Int())
+		case "cols":
+			out.Cols = int(in.Int())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -2152,42 +5565,47 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol23(in *jlexer.
 		in.Consumed()
 	}
 }
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol23(out *jwriter.Writer, in ChangeNicknameParams) {
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol54(out *jwriter.Writer, in ChangeBoardSizeParams) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"nickname\":"
+		const prefix string = ",\"rows\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Nickname))
+		out.Int(int(in.Rows))
+	}
+	{
+		const prefix string = ",\"cols\":"
+		out.RawString(prefix)
+		out.Int(int(in.Cols))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ChangeNicknameParams) MarshalJSON() ([]byte, error) {
+func (v ChangeBoardSizeParams) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol23(&w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol54(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ChangeNicknameParams) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol23(w, v)
+func (v ChangeBoardSizeParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol54(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ChangeNicknameParams) UnmarshalJSON(data []byte) error {
+func (v *ChangeBoardSizeParams) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol23(&r, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol54(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ChangeNicknameParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol23(l, v)
+func (v *ChangeBoardSizeParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol54(l, v)
 }
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol24(in *jlexer.Lexer, out *ChangeHideBombParams) {
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol55(in *jlexer.Lexer, out *BanParams) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2206,8 +5624,10 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol24(in *jlexer.
 			continue
 		}
 		switch key {
-		case "hideBomb":
-			out.HideBomb = bool(in.Bool())
+		case "playerID":
+			out.PlayerID = string(in.String())
+		case "nickname":
+			out.Nickname = string(in.String())
 		default:
 			in.AddError(&jlexer.LexerError{
 				Offset: in.GetPos(),
@@ -2222,42 +5642,53 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol24(in *jlexer.
 		in.Consumed()
 	}
 }
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol24(out *jwriter.Writer, in ChangeHideBombParams) {
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol55(out *jwriter.Writer, in BanParams) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	{
-		const prefix string = ",\"hideBomb\":"
+	if in.PlayerID != "" {
+		const prefix string = ",\"playerID\":"
+		first = false
 		out.RawString(prefix[1:])
-		out.Bool(bool(in.HideBomb))
+		out.String(string(in.PlayerID))
+	}
+	if in.Nickname != "" {
+		const prefix string = ",\"nickname\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Nickname))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ChangeHideBombParams) MarshalJSON() ([]byte, error) {
+func (v BanParams) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol24(&w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol55(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ChangeHideBombParams) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol24(w, v)
+func (v BanParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol55(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ChangeHideBombParams) UnmarshalJSON(data []byte) error {
+func (v *BanParams) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol24(&r, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol55(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ChangeHideBombParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol24(l, v)
+func (v *BanParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol55(l, v)
 }
-func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol25(in *jlexer.Lexer, out *AddPacksParams) {
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol56(in *jlexer.Lexer, out *AddPacksParams) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2298,12 +5729,12 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol25(in *jlexer.
 					out.Packs = (out.Packs)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v19 struct {
+					var v61 struct {
 						Name  string   `json:"name"`
 						Words []string `json:"words"`
 					}
-					easyjsonE4425964Decode(in, &v19)
-					out.Packs = append(out.Packs, v19)
+					easyjsonE4425964Decode(in, &v61)
+					out.Packs = append(out.Packs, v61)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2322,7 +5753,7 @@ func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol25(in *jlexer.
 		in.Consumed()
 	}
 }
-func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol25(out *jwriter.Writer, in AddPacksParams) {
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol56(out *jwriter.Writer, in AddPacksParams) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -2333,11 +5764,11 @@ func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol25(out *jwrite
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v20, v21 := range in.Packs {
-				if v20 > 0 {
+			for v62, v63 := range in.Packs {
+				if v62 > 0 {
 					out.RawByte(',')
 				}
-				easyjsonE4425964Encode(out, v21)
+				easyjsonE4425964Encode(out, v63)
 			}
 			out.RawByte(']')
 		}
@@ -2348,25 +5779,25 @@ func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol25(out *jwrite
 // MarshalJSON supports json.Marshaler interface
 func (v AddPacksParams) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol25(&w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol56(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v AddPacksParams) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol25(w, v)
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol56(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *AddPacksParams) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol25(&r, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol56(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *AddPacksParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol25(l, v)
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol56(l, v)
 }
 func easyjsonE4425964Decode(in *jlexer.Lexer, out *struct {
 	Name  string   `json:"name"`
@@ -2408,9 +5839,9 @@ func easyjsonE4425964Decode(in *jlexer.Lexer, out *struct {
 					out.Words = (out.Words)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v22 string
-					v22 = string(in.String())
-					out.Words = append(out.Words, v22)
+					var v64 string
+					v64 = string(in.String())
+					out.Words = append(out.Words, v64)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2448,14 +5879,84 @@ func easyjsonE4425964Encode(out *jwriter.Writer, in struct {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v23, v24 := range in.Words {
-				if v23 > 0 {
+			for v65, v66 := range in.Words {
+				if v65 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v24))
+				out.String(string(v66))
 			}
 			out.RawByte(']')
 		}
 	}
 	out.RawByte('}')
 }
+func easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol57(in *jlexer.Lexer, out *AckParams) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "version":
+			out.Version = int(in.Int())
+		default:
+			in.AddError(&jlexer.LexerError{
+				Offset: in.GetPos(),
+				Reason: "unknown field",
+				Data:   key,
+			})
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol57(out *jwriter.Writer, in AckParams) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"version\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Version))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AckParams) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol57(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AckParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE4425964EncodeGithubComZikaerohCodiesInternalProtocol57(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AckParams) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol57(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AckParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE4425964DecodeGithubComZikaerohCodiesInternalProtocol57(l, v)
+}
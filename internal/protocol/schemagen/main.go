@@ -0,0 +1,619 @@
+// Command schemagen generates a TypeScript module and a JSON Schema document
+// describing the protocol package, for clients which don't share its Go types.
+//
+// The types are those marked for easyjson, along with the named types they
+// use. String types with constants in their package become unions of those
+// constants. Client notes are built from the ClientMethod constants and their
+// matching Params types; server notes from the NewXNote functions. Pointers
+// are nullable, unless omitted when empty or used as slice elements, which the
+// server never leaves nil.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const header = "Code generated by schemagen. DO NOT EDIT."
+
+// printWidth matches the frontend's prettier configuration.
+const printWidth = 120
+
+func main() {
+	dir := flag.String("dir", ".", "directory of the protocol package")
+	tsOut := flag.String("ts", "", "TypeScript output file")
+	schemaOut := flag.String("schema", "", "JSON Schema output file")
+	flag.Parse()
+
+	if *tsOut == "" || *schemaOut == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ts, schema, err := generate(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(*tsOut, ts, 0o644); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(*schemaOut, schema, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate loads the protocol package in dir, returning the TypeScript module
+// and JSON Schema document for it.
+func generate(dir string) (ts []byte, schema []byte, err error) {
+	p, err := load(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	g := &generator{
+		pkg:  p,
+		seen: make(map[*types.TypeName]bool),
+		defs: make(map[string]interface{}),
+	}
+
+	if err := g.run(); err != nil {
+		return nil, nil, err
+	}
+
+	schema, err = json.MarshalIndent(map[string]interface{}{
+		"$schema":     "http://json-schema.org/draft-07/schema#",
+		"$comment":    header,
+		"definitions": g.defs,
+	}, "", "    ")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return g.ts.Bytes(), append(schema, '\n'), nil
+}
+
+// method is a note method, with the type of its params.
+type method struct {
+	name   string
+	params *types.TypeName
+}
+
+type protocolPackage struct {
+	named  []*types.TypeName // The easyjson types, in source order.
+	client []method
+	server []method
+}
+
+// noteTypes are described by their methods rather than their fields.
+var noteTypes = map[string]bool{"ClientNote": true, "ServerNote": true}
+
+func load(dir string) (*protocolPackage, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := pkgs["protocol"]
+	if pkg == nil {
+		return nil, fmt.Errorf("no protocol package in %s", dir)
+	}
+
+	// Sort the files, so the types are found in the same order each time.
+	var files []*ast.File
+	names := make([]string, 0, len(pkg.Files))
+	for name := range pkg.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		files = append(files, pkg.Files[name])
+	}
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	tpkg, err := conf.Check(abs, fset, files, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &protocolPackage{}
+
+	lookup := func(name string) (*types.TypeName, error) {
+		tn, ok := tpkg.Scope().Lookup(name).(*types.TypeName)
+		if !ok {
+			return nil, fmt.Errorf("no type %s", name)
+		}
+		return tn, nil
+	}
+
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						if !easyjsonType(decl.Doc) && !easyjsonType(spec.Doc) {
+							continue
+						}
+						tn, err := lookup(spec.Name.Name)
+						if err != nil {
+							return nil, err
+						}
+						p.named = append(p.named, tn)
+
+					case *ast.ValueSpec:
+						for _, name := range spec.Names {
+							m, ok, err := clientMethod(tpkg, name.Name)
+							if err != nil {
+								return nil, err
+							}
+							if ok {
+								p.client = append(p.client, m)
+							}
+						}
+					}
+				}
+
+			case *ast.FuncDecl:
+				if decl.Recv != nil || !strings.HasPrefix(decl.Name.Name, "New") || !strings.HasSuffix(decl.Name.Name, "Note") {
+					continue
+				}
+				name, params, err := serverMethod(decl)
+				if err != nil {
+					return nil, err
+				}
+				tn, err := lookup(params)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", decl.Name.Name, err)
+				}
+				p.server = append(p.server, method{name: name, params: tn})
+			}
+		}
+	}
+
+	return p, nil
+}
+
+func easyjsonType(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+	for _, c := range doc.List {
+		if strings.TrimSpace(c.Text) == "//easyjson:json" {
+			return true
+		}
+	}
+	return false
+}
+
+// clientMethod returns the client method for a constant named XMethod of type
+// ClientMethod, whose params have type XParams.
+func clientMethod(pkg *types.Package, name string) (method, bool, error) {
+	c, ok := pkg.Scope().Lookup(name).(*types.Const)
+	if !ok || !strings.HasSuffix(name, "Method") {
+		return method{}, false, nil
+	}
+
+	if named, ok := c.Type().(*types.Named); !ok || named.Obj().Name() != "ClientMethod" {
+		return method{}, false, nil
+	}
+
+	paramsName := strings.TrimSuffix(name, "Method") + "Params"
+	params, ok := pkg.Scope().Lookup(paramsName).(*types.TypeName)
+	if !ok {
+		return method{}, false, fmt.Errorf("%s has no %s", name, paramsName)
+	}
+
+	return method{name: constant.StringVal(c.Val()), params: params}, true, nil
+}
+
+// serverMethod finds the ServerNote literal returned by a NewXNote function,
+// with Method set to a string and Params to the address of a composite literal,
+// returning the method and the name of the params type.
+func serverMethod(decl *ast.FuncDecl) (name string, params string, err error) {
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		kv, ok := n.(*ast.KeyValueExpr)
+		if !ok {
+			return true
+		}
+
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		switch key.Name {
+		case "Method":
+			if lit, ok := kv.Value.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				name, _ = strconv.Unquote(lit.Value)
+			}
+		case "Params":
+			if u, ok := kv.Value.(*ast.UnaryExpr); ok && u.Op == token.AND {
+				if cl, ok := u.X.(*ast.CompositeLit); ok {
+					if id, ok := cl.Type.(*ast.Ident); ok {
+						params = id.Name
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	if name == "" || params == "" {
+		return "", "", fmt.Errorf("%s: no ServerNote with a literal method and params", decl.Name.Name)
+	}
+	return name, params, nil
+}
+
+type generator struct {
+	pkg   *protocolPackage
+	ts    bytes.Buffer
+	queue []*types.TypeName
+	seen  map[*types.TypeName]bool
+	defs  map[string]interface{}
+}
+
+func (g *generator) run() error {
+	g.printf("// %s\n", header)
+	g.printf("// Source: internal/protocol; see protocol.go.\n")
+
+	for _, tn := range g.pkg.named {
+		if !noteTypes[tn.Name()] {
+			g.use(tn)
+		}
+	}
+
+	for len(g.queue) > 0 {
+		tn := g.queue[0]
+		g.queue = g.queue[1:]
+		if err := g.define(tn); err != nil {
+			return err
+		}
+	}
+
+	g.notes("Client", g.pkg.client, true)
+	g.notes("Server", g.pkg.server, false)
+	return nil
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.ts, format, args...)
+}
+
+// use queues a named type to be defined.
+func (g *generator) use(tn *types.TypeName) {
+	if !g.seen[tn] {
+		g.seen[tn] = true
+		g.queue = append(g.queue, tn)
+	}
+}
+
+func (g *generator) define(tn *types.TypeName) error {
+	name := tn.Name()
+	g.printf("\n")
+
+	switch u := tn.Type().Underlying().(type) {
+	case *types.Struct:
+		ts, schema, err := g.object(u, false)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		g.printf("export interface %s %s\n", name, ts)
+		g.defs[name] = schema
+
+	case *types.Basic:
+		values := g.constants(tn)
+		if len(values) == 0 {
+			ts, schema, err := g.typ(u)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			g.printf("export type %s = %s;\n", name, ts)
+			g.defs[name] = schema
+			return nil
+		}
+
+		lits := make([]string, len(values))
+		enum := make([]interface{}, len(values))
+		for i, v := range values {
+			lits[i] = tsString(v)
+			enum[i] = v
+		}
+		g.union(name, lits)
+		g.defs[name] = map[string]interface{}{"type": "string", "enum": enum}
+
+	default:
+		return fmt.Errorf("%s: unsupported type %s", name, u)
+	}
+
+	return nil
+}
+
+// constants returns the values of the string constants of a named type, in
+// the order they were declared.
+func (g *generator) constants(tn *types.TypeName) []string {
+	scope := tn.Pkg().Scope()
+
+	var consts []*types.Const
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if ok && types.Identical(c.Type(), tn.Type()) && c.Val().Kind() == constant.String {
+			consts = append(consts, c)
+		}
+	}
+
+	sort.Slice(consts, func(i, j int) bool { return consts[i].Pos() < consts[j].Pos() })
+
+	values := make([]string, len(consts))
+	for i, c := range consts {
+		values[i] = constant.StringVal(c.Val())
+	}
+	return values
+}
+
+// object describes a struct's fields. Named structs are written one field per
+// line; anonymous ones inline.
+func (g *generator) object(s *types.Struct, inline bool) (string, map[string]interface{}, error) {
+	props := make(map[string]interface{})
+	required := make([]interface{}, 0, s.NumFields())
+	var fields []string
+
+	for i := 0; i < s.NumFields(); i++ {
+		f := s.Field(i)
+		if !f.Exported() {
+			continue
+		}
+		if f.Embedded() {
+			return "", nil, fmt.Errorf("embedded field %s is unsupported", f.Name())
+		}
+
+		name, omitempty, ok := jsonName(f.Name(), s.Tag(i))
+		if !ok {
+			continue
+		}
+
+		typ := f.Type()
+		optional := ""
+		if omitempty {
+			// Omitted pointers are never null.
+			if ptr, ok := typ.(*types.Pointer); ok {
+				typ = ptr.Elem()
+			}
+			optional = "?"
+		} else {
+			required = append(required, name)
+		}
+
+		ts, schema, err := g.typ(typ)
+		if err != nil {
+			return "", nil, fmt.Errorf("field %s: %w", f.Name(), err)
+		}
+
+		fields = append(fields, name+optional+": "+ts+";")
+		props[name] = schema
+	}
+
+	schema := map[string]interface{}{
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	switch {
+	case len(fields) == 0:
+		return "{}", schema, nil
+	case inline:
+		return "{ " + strings.TrimSuffix(strings.Join(fields, " "), ";") + " }", schema, nil
+	default:
+		return "{\n    " + strings.Join(fields, "\n    ") + "\n}", schema, nil
+	}
+}
+
+func jsonName(field string, tag string) (name string, omitempty bool, ok bool) {
+	parts := strings.Split(reflect.StructTag(tag).Get("json"), ",")
+	if parts[0] == "-" && len(parts) == 1 {
+		return "", false, false
+	}
+
+	name = parts[0]
+	if name == "" {
+		name = field
+	}
+
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitempty = true
+		}
+	}
+	return name, omitempty, true
+}
+
+var errUnsupported = errors.New("unsupported type")
+
+// typ describes a type used by a field. Named types are referenced by name.
+func (g *generator) typ(t types.Type) (string, map[string]interface{}, error) {
+	switch t := t.(type) {
+	case *types.Named:
+		switch t.Obj().Pkg().Path() + "." + t.Obj().Name() {
+		case "time.Time":
+			return "string", map[string]interface{}{"type": "string", "format": "date-time"}, nil
+		case "github.com/mailru/easyjson.RawMessage":
+			return "unknown", map[string]interface{}{}, nil
+		}
+
+		if _, ok := t.Underlying().(*types.Interface); ok {
+			return g.typ(t.Underlying())
+		}
+
+		g.use(t.Obj())
+		return t.Obj().Name(), ref(t.Obj().Name()), nil
+
+	case *types.Basic:
+		switch {
+		case t.Info()&types.IsString != 0:
+			return "string", map[string]interface{}{"type": "string"}, nil
+		case t.Info()&types.IsBoolean != 0:
+			return "boolean", map[string]interface{}{"type": "boolean"}, nil
+		case t.Info()&types.IsInteger != 0:
+			return "number", map[string]interface{}{"type": "integer"}, nil
+		case t.Info()&types.IsFloat != 0:
+			return "number", map[string]interface{}{"type": "number"}, nil
+		}
+
+	case *types.Pointer:
+		ts, schema, err := g.typ(t.Elem())
+		if err != nil {
+			return "", nil, err
+		}
+		return ts + " | null", map[string]interface{}{
+			"anyOf": []interface{}{schema, map[string]interface{}{"type": "null"}},
+		}, nil
+
+	case *types.Slice:
+		// The server never leaves pointers in slices nil.
+		elem := t.Elem()
+		if ptr, ok := elem.(*types.Pointer); ok {
+			elem = ptr.Elem()
+		}
+		ts, schema, err := g.typ(elem)
+		if err != nil {
+			return "", nil, err
+		}
+		if strings.Contains(ts, " | ") {
+			ts = "(" + ts + ")"
+		}
+		return ts + "[]", map[string]interface{}{"type": "array", "items": schema}, nil
+
+	case *types.Map:
+		if b, ok := t.Key().Underlying().(*types.Basic); !ok || b.Info()&types.IsString == 0 {
+			break
+		}
+		ts, schema, err := g.typ(t.Elem())
+		if err != nil {
+			return "", nil, err
+		}
+		return "Record<string, " + ts + ">", map[string]interface{}{"type": "object", "additionalProperties": schema}, nil
+
+	case *types.Interface:
+		if t.Empty() {
+			return "unknown", map[string]interface{}{}, nil
+		}
+
+	case *types.Struct:
+		return g.object(t, true)
+
+	default:
+		// Aliases, if the type checker records them.
+		if u := t.Underlying(); u != t {
+			return g.typ(u)
+		}
+	}
+
+	return "", nil, fmt.Errorf("%w %s", errUnsupported, t)
+}
+
+func ref(name string) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/definitions/" + name}
+}
+
+// notes writes the union of the notes sent by the client or the server. Client
+// notes also have an optional ID and the version they apply to.
+func (g *generator) notes(prefix string, methods []method, client bool) {
+	names := make([]string, len(methods))
+	variants := make([]interface{}, len(methods))
+
+	g.printf("\nexport interface %sParams {\n", prefix)
+	for i, m := range methods {
+		names[i] = tsString(m.name)
+		g.printf("    %s: %s;\n", m.name, m.params.Name())
+
+		props := map[string]interface{}{
+			"method": map[string]interface{}{"const": m.name},
+			"params": ref(m.params.Name()),
+		}
+		required := []interface{}{"method", "params"}
+		if client {
+			props["id"] = map[string]interface{}{"type": "integer"}
+			props["version"] = map[string]interface{}{"type": "integer"}
+			required = []interface{}{"method", "version", "params"}
+		}
+
+		variants[i] = map[string]interface{}{
+			"type":                 "object",
+			"properties":           props,
+			"required":             required,
+			"additionalProperties": false,
+		}
+	}
+	g.printf("}\n")
+
+	g.printf("\nexport type %sMethod = keyof %sParams;\n", prefix, prefix)
+
+	fields := ""
+	if client {
+		fields = " id?: number; version: number;"
+	}
+	g.printf("\nexport type %sNote = {\n", prefix)
+	g.printf("    [M in %sMethod]: {%s method: M; params: %sParams[M] };\n", prefix, fields, prefix)
+	g.printf("}[%sMethod];\n", prefix)
+
+	methodEnum := make([]interface{}, len(methods))
+	for i, m := range methods {
+		methodEnum[i] = m.name
+	}
+	g.defs[prefix+"Method"] = map[string]interface{}{"type": "string", "enum": methodEnum}
+	g.defs[prefix+"Note"] = map[string]interface{}{"oneOf": variants}
+}
+
+// union writes a union type, one member per line if it is too long for a line,
+// as prettier would.
+func (g *generator) union(name string, members []string) {
+	line := fmt.Sprintf("export type %s = %s;", name, strings.Join(members, " | "))
+	if len(line) <= printWidth {
+		g.printf("%s\n", line)
+		return
+	}
+
+	g.printf("export type %s =\n", name)
+	for i, m := range members {
+		end := ""
+		if i == len(members)-1 {
+			end = ";"
+		}
+		g.printf("    | %s%s\n", m, end)
+	}
+}
+
+func tsString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestGeneratedUpToDate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("type checks the protocol package and its imports from source")
+	}
+
+	ts, schema, err := generate("..")
+	assert.NilError(t, err)
+
+	for path, want := range map[string][]byte{
+		"../../../frontend/src/protocol/generated.ts": ts,
+		"../schema.json": schema,
+	} {
+		got, err := ioutil.ReadFile(path)
+		assert.NilError(t, err)
+		assert.Equal(t, string(got), string(want), "%s is stale; run go generate in internal/protocol", path)
+	}
+}
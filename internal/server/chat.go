@@ -0,0 +1,155 @@
+package server
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/zikaeroh/codies/internal/game"
+	"github.com/zikaeroh/codies/internal/protocol"
+	"golang.org/x/time/rate"
+)
+
+const (
+	maxChatLength     = 500 // In runes.
+	maxChatScrollback = 100
+
+	// Each player may send a burst of chatBurst messages, then one every
+	// chatInterval.
+	chatBurst    = 5
+	chatInterval = time.Second
+)
+
+var errChatTooLong = errors.New("server: chat message too long")
+
+// chatMessage is a message in a room's scrollback.
+type chatMessage struct {
+	msg *protocol.ChatMessage
+
+	// Players who may see a team-only message, decided when it was sent, so
+	// that changing teams or roles never reveals earlier messages.
+	recipients map[game.PlayerID]bool
+}
+
+func (m *chatMessage) visibleTo(playerID game.PlayerID) bool {
+	return m.msg.Team == nil || m.recipients[playerID]
+}
+
+// chat sends a message from a player to the room, or only to their team.
+// Empty messages are ignored; messages which are too long, sent too quickly,
+// or to a team chat the player can't use are dropped with an error.
+//
+// Must be called with r.mu locked.
+func (r *Room) chat(playerID game.PlayerID, text string, teamOnly bool) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+
+	if utf8.RuneCountInString(text) > maxChatLength {
+		return errChatTooLong
+	}
+
+	var nickname string
+	var team *game.Team
+
+	if cl := r.players[playerID]; cl != nil && cl.spectator {
+		if teamOnly {
+			return errForbidden
+		}
+		nickname = cl.nickname
+	} else {
+		p := r.room.Players[playerID]
+		if p == nil {
+			return game.ErrUnknownPlayer
+		}
+		nickname = p.Nickname
+
+		if teamOnly {
+			if p.Spymaster && r.hideTeamChat {
+				return errForbidden
+			}
+			t := p.Team
+			team = &t
+		}
+	}
+
+	if !r.chatLimiter(playerID).Allow() {
+		return ErrRateLimited
+	}
+
+	r.chatID++
+	m := &chatMessage{
+		msg: &protocol.ChatMessage{
+			ID:       r.chatID,
+			PlayerID: playerID,
+			Nickname: nickname,
+			Text:     text,
+			Team:     team,
+			Time:     time.Now(),
+		},
+	}
+
+	if team != nil {
+		m.recipients = make(map[game.PlayerID]bool)
+		for _, id := range r.room.Teams[*team] {
+			if !r.room.Players[id].Spymaster || !r.hideTeamChat {
+				m.recipients[id] = true
+			}
+		}
+	}
+
+	r.chatLog = append(r.chatLog, m)
+	if len(r.chatLog) > maxChatScrollback {
+		r.chatLog = r.chatLog[len(r.chatLog)-maxChatScrollback:]
+	}
+
+	note := protocol.NewChatNote([]*protocol.ChatMessage{m.msg})
+	for id, cl := range r.players {
+		if m.visibleTo(id) {
+			cl.send(note)
+		}
+	}
+
+	return nil
+}
+
+// Must be called with r.mu locked.
+func (r *Room) chatLimiter(playerID game.PlayerID) *rate.Limiter {
+	l := r.chatLimits[playerID]
+	if l == nil {
+		if r.chatLimits == nil {
+			r.chatLimits = make(map[game.PlayerID]*rate.Limiter)
+		}
+		l = rate.NewLimiter(rate.Every(chatInterval), chatBurst)
+		r.chatLimits[playerID] = l
+	}
+	return l
+}
+
+// sendChatLog sends a client the scrollback it may see.
+//
+// Must be called with r.mu locked.
+func (r *Room) sendChatLog(playerID game.PlayerID, cl *client) {
+	var messages []*protocol.ChatMessage
+	for _, m := range r.chatLog {
+		if m.visibleTo(playerID) {
+			messages = append(messages, m.msg)
+		}
+	}
+
+	if len(messages) != 0 {
+		cl.send(protocol.NewChatNote(messages))
+	}
+}
+
+// Must be called with r.mu locked.
+func (r *Room) changeHideTeamChat(hideTeamChat bool) {
+	if r.hideTeamChat == hideTeamChat {
+		return
+	}
+
+	r.hideTeamChat = hideTeamChat
+	r.room.Version++
+}
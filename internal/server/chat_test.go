@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/zikaeroh/codies/internal/game"
+	"github.com/zikaeroh/codies/internal/protocol"
+	"gotest.tools/v3/assert"
+)
+
+func TestChat(t *testing.T) {
+	s, stop := runServer(t, nil)
+	defer stop()
+
+	room, err := s.CreateRoom(context.Background(), "", "room", "", false)
+	assert.NilError(t, err)
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	received := make(map[game.PlayerID][]string)
+	connect := func(id game.PlayerID, spectator bool) {
+		cl := newFakeClient(room, id)
+		cl.spectator = spectator
+		cl.send = func(n protocol.ServerNote) {
+			if chat, ok := n.Params.(*protocol.Chat); ok {
+				for _, m := range chat.Messages {
+					received[id] = append(received[id], m.Text)
+				}
+			}
+		}
+		room.connect(id, string(id), cl.client)
+	}
+
+	connect("1", false)
+	connect("2", false)
+	connect("3", false)
+	connect("4", true)
+
+	team := room.room.Players["1"].Team
+	room.room.ChangeTeam("3", team)
+	room.room.ChangeTeam("2", 1-team)
+	room.room.ChangeRole("3", true)
+
+	room.chat("1", "hello", false)
+	room.chat("1", "team", true)
+	assert.Equal(t, room.chat("1", strings.Repeat("x", maxChatLength+1), false), errChatTooLong)
+	room.chat("4", "watching", false)
+	assert.Equal(t, room.chat("4", "sneaky", true), errForbidden)
+
+	assert.DeepEqual(t, received["1"], []string{"hello", "team", "watching"})
+	assert.DeepEqual(t, received["2"], []string{"hello", "watching"})
+	assert.DeepEqual(t, received["3"], []string{"hello", "team", "watching"})
+	assert.DeepEqual(t, received["4"], []string{"hello", "watching"})
+
+	// Spymasters can be left out of team chat.
+	room.changeHideTeamChat(true)
+	room.chat("1", "secret", true)
+	assert.Equal(t, room.chat("3", "hint", true), errForbidden)
+	assert.DeepEqual(t, received["3"], []string{"hello", "team", "watching"})
+
+	// Changing teams doesn't reveal earlier team chat.
+	received["2"] = nil
+	room.room.ChangeTeam("2", team)
+	room.sendChatLog("2", room.players["2"])
+	assert.DeepEqual(t, received["2"], []string{"hello", "watching"})
+
+	// Players are rate limited.
+	for i := 0; i < chatBurst; i++ {
+		assert.NilError(t, room.chat("2", "spam", false))
+	}
+	assert.Equal(t, room.chat("2", "spam", false), ErrRateLimited)
+	assert.Equal(t, len(received["2"]), 2+chatBurst)
+	assert.Equal(t, resultCode(ErrRateLimited), protocol.ResultRateLimited)
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/zikaeroh/codies/internal/game"
+	"gopkg.in/yaml.v2"
+)
+
+// Config holds the server's limits. It's tagged to be filled from flags and
+// environment variables with go-flags, and from a YAML file with LoadConfig.
+type Config struct {
+	MaxRooms      int           `long:"max-rooms" env:"CODIES_MAX_ROOMS" yaml:"maxRooms" description:"Maximum number of rooms"`
+	PruneAfter    time.Duration `long:"prune-after" env:"CODIES_PRUNE_AFTER" yaml:"pruneAfter" description:"Remove rooms nobody has used for this long"`
+	PruneInterval time.Duration `long:"prune-interval" env:"CODIES_PRUNE_INTERVAL" yaml:"pruneInterval" description:"How often to look for rooms to remove"`
+	PingInterval  time.Duration `long:"ping-interval" env:"CODIES_PING_INTERVAL" yaml:"pingInterval" description:"How often to ping WebSocket clients"`
+	TurnSeconds   int           `long:"turn-seconds" env:"CODIES_TURN_SECONDS" yaml:"turnSeconds" description:"Turn time of new rooms, in seconds"`
+	MaxPacks      int           `long:"max-packs" env:"CODIES_MAX_PACKS" yaml:"maxPacks" description:"Maximum number of word packs in a room, including the default packs"`
+	MaxRoomName   int           `long:"max-room-name" env:"CODIES_MAX_ROOM_NAME" yaml:"maxRoomName" description:"Maximum length of room names"`
+	MaxNickname   int           `long:"max-nickname" env:"CODIES_MAX_NICKNAME" yaml:"maxNickname" description:"Maximum length of nicknames"`
+
+	MaxFailedJoins   int           `long:"max-failed-joins" env:"CODIES_MAX_FAILED_JOINS" yaml:"maxFailedJoins" description:"Maximum failed attempts to join rooms, and rooms created, from an address in each window"`
+	FailedJoinWindow time.Duration `long:"failed-join-window" env:"CODIES_FAILED_JOIN_WINDOW" yaml:"failedJoinWindow" description:"How long failed attempts to join rooms, and rooms created, are counted for"`
+
+	MaxInviteAge time.Duration `long:"max-invite-age" env:"CODIES_MAX_INVITE_AGE" yaml:"maxInviteAge" description:"Longest time an invite lasts before expiring"`
+}
+
+// DefaultConfig returns the default limits.
+func DefaultConfig() Config {
+	return Config{
+		MaxRooms:      1000,
+		PruneAfter:    10 * time.Minute,
+		PruneInterval: 5 * time.Minute,
+		PingInterval:  time.Minute,
+		TurnSeconds:   60,
+		MaxPacks:      game.DefaultMaxPacks,
+		MaxRoomName:   20,
+		MaxNickname:   16,
+
+		MaxFailedJoins:   10,
+		FailedJoinWindow: 10 * time.Minute,
+
+		MaxInviteAge: 24 * time.Hour,
+	}
+}
+
+// LoadConfig reads a YAML file into c. Limits missing from the file are left
+// as they are.
+func LoadConfig(path string, c *Config) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return yaml.UnmarshalStrict(b, c)
+}
+
+// Validate checks that the limits are usable.
+func (c *Config) Validate() error {
+	for _, v := range []struct {
+		name  string
+		value int64
+	}{
+		{"maxRooms", int64(c.MaxRooms)},
+		{"pruneAfter", int64(c.PruneAfter)},
+		{"pruneInterval", int64(c.PruneInterval)},
+		{"pingInterval", int64(c.PingInterval)},
+		{"turnSeconds", int64(c.TurnSeconds)},
+		{"maxPacks", int64(c.MaxPacks)},
+		{"maxRoomName", int64(c.MaxRoomName)},
+		{"maxNickname", int64(c.MaxNickname)},
+		{"maxFailedJoins", int64(c.MaxFailedJoins)},
+		{"failedJoinWindow", int64(c.FailedJoinWindow)},
+		{"maxInviteAge", int64(c.MaxInviteAge)},
+	} {
+		if v.value <= 0 {
+			return fmt.Errorf("server: %s must be positive", v.name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/zikaeroh/codies/internal/game"
+	"gotest.tools/v3/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "codies")
+	assert.NilError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "config.yaml")
+	assert.NilError(t, ioutil.WriteFile(path, []byte("maxRooms: 5\npruneAfter: 30m\n"), 0o644))
+
+	config := DefaultConfig()
+	assert.NilError(t, LoadConfig(path, &config))
+	assert.Equal(t, config.MaxRooms, 5)
+	assert.Equal(t, config.PruneAfter, 30*time.Minute)
+	assert.Equal(t, config.TurnSeconds, DefaultConfig().TurnSeconds)
+	assert.NilError(t, config.Validate())
+
+	assert.NilError(t, ioutil.WriteFile(path, []byte("maxRoom: 5\n"), 0o644))
+	assert.ErrorContains(t, LoadConfig(path, &config), "maxRoom")
+
+	config.PingInterval = 0
+	assert.ErrorContains(t, config.Validate(), "pingInterval")
+}
+
+func TestConfigLimits(t *testing.T) {
+	config := DefaultConfig()
+	config.MaxRooms = 1
+	config.MaxPacks = 3 // Only the default packs.
+
+	s, stop := runServerConfig(t, config, nil)
+	defer stop()
+
+	room, err := s.CreateRoom(context.Background(), "", "room", "", false)
+	assert.NilError(t, err)
+
+	_, err = s.CreateRoom(context.Background(), "", "other", "", false)
+	assert.Equal(t, err, ErrTooManyRooms)
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+	assert.Equal(t, room.room.AddPack("custom", make([]string, 25)), game.ErrTooManyPacks)
+}
@@ -0,0 +1,170 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mailru/easyjson"
+	"github.com/zikaeroh/codies/internal/game"
+	"github.com/zikaeroh/codies/internal/protocol"
+	"nhooyr.io/websocket"
+)
+
+const (
+	// eventBuffer is how many notes may be queued for an event stream before
+	// the client is considered too slow and disconnected.
+	eventBuffer = 64
+
+	// eventHeartbeat is how often a comment is sent on an idle event stream,
+	// to keep proxies from closing it.
+	eventHeartbeat = 30 * time.Second
+)
+
+var (
+	ErrNotConnected = errors.New("server: not connected")
+	ErrInvalidToken = errors.New("server: invalid token")
+)
+
+// HandleEvents handles a client connected over server-sent events, for
+// clients which can't use WebSockets. Notes are sent as JSON message events;
+// when the room closes the connection, a close event is sent with the code and
+// reason a WebSocket would have been closed with. The client sends notes with
+// HandleAction, using the token from its state notes.
+func (r *Room) HandleEvents(ctx context.Context, query *protocol.WSQuery, w http.ResponseWriter) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("X-Accel-Buffering", "no") // Stop nginx from buffering the stream.
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	t := &sseTransport{
+		w:       w,
+		flusher: flusher,
+		notes:   make(chan []byte, eventBuffer),
+		closing: make(chan struct{}),
+	}
+	r.serve(ctx, query, t)
+
+	// Refused clients are closed before the transport runs.
+	_ = t.writeClose()
+}
+
+// HandleAction handles a note sent by a client connected with HandleEvents,
+// identified by its token. Results are sent over the event stream.
+func (r *Room) HandleAction(ctx context.Context, token string, note *protocol.ClientNote) error {
+	playerID, ok := r.verifyToken(token)
+	if !ok {
+		return ErrInvalidToken
+	}
+
+	r.mu.Lock()
+	connected := r.players[playerID] != nil
+	r.mu.Unlock()
+
+	if !connected {
+		return ErrNotConnected
+	}
+
+	return r.receive(ctx, playerID, note)
+}
+
+type sseTransport struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+	notes   chan []byte
+
+	closeOnce sync.Once
+	closing   chan struct{}
+	code      websocket.StatusCode
+	reason    string
+	closed    bool // Set once the close event is written.
+}
+
+var _ transport = (*sseTransport)(nil)
+
+func (t *sseTransport) send(ctx context.Context, s protocol.ServerNote) {
+	b, err := protocol.CodecFor("").MarshalServerNote(&s)
+	if err != nil {
+		return
+	}
+
+	select {
+	case t.notes <- b:
+	default:
+		// Dropping notes would break patches; make the client start over.
+		t.close(websocket.StatusTryAgainLater, "too far behind")
+	}
+}
+
+func (t *sseTransport) close(code websocket.StatusCode, reason string) {
+	t.closeOnce.Do(func() {
+		t.code = code
+		t.reason = reason
+		close(t.closing)
+	})
+}
+
+func (t *sseTransport) run(ctx context.Context, r *Room, playerID game.PlayerID) error {
+	ticker := time.NewTicker(eventHeartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-t.closing:
+			return t.writeClose()
+
+		case b := <-t.notes:
+			if _, err := fmt.Fprintf(t.w, "data: %s\n\n", b); err != nil {
+				return err
+			}
+			t.flusher.Flush()
+			metricSent.Inc()
+
+		case <-ticker.C:
+			if _, err := fmt.Fprint(t.w, ": ping\n\n"); err != nil {
+				return err
+			}
+			t.flusher.Flush()
+			r.lastSeen.Store(time.Now())
+		}
+	}
+}
+
+// writeClose writes the close event, if the connection has been closed. It
+// must only be called by the goroutine running the transport.
+func (t *sseTransport) writeClose() error {
+	select {
+	case <-t.closing:
+	default:
+		return nil
+	}
+
+	if t.closed {
+		return nil
+	}
+	t.closed = true
+
+	b, err := easyjson.Marshal(&protocol.CloseEvent{Code: int(t.code), Reason: t.reason})
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(t.w, "event: close\ndata: %s\n\n", b); err != nil {
+		return err
+	}
+	t.flusher.Flush()
+	return nil
+}
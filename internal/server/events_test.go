@@ -0,0 +1,101 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zikaeroh/codies/internal/protocol"
+	"gotest.tools/v3/assert"
+)
+
+type event struct {
+	name string
+	data string
+}
+
+func readEvent(t *testing.T, r *bufio.Reader) event {
+	t.Helper()
+
+	var e event
+	for {
+		line, err := r.ReadString('\n')
+		assert.NilError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+
+		switch {
+		case line == "":
+			if e.data != "" {
+				return e
+			}
+		case strings.HasPrefix(line, "event: "):
+			e.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			e.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func TestEvents(t *testing.T) {
+	s, stop := runServer(t, nil)
+	defer stop()
+
+	room, err := s.CreateRoom(context.Background(), "", "room", "", false)
+	assert.NilError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		room.HandleEvents(r.Context(), &protocol.WSQuery{RoomID: room.ID, Key: room.Key(), Nickname: "player"}, w)
+	}))
+	defer ts.Close()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+	assert.NilError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	assert.NilError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, resp.Header.Get("Content-Type"), "text/event-stream")
+
+	body := bufio.NewReader(resp.Body)
+
+	var state struct {
+		Method string          `json:"method"`
+		Params *protocol.State `json:"params"`
+	}
+	assert.NilError(t, json.Unmarshal([]byte(readEvent(t, body).data), &state))
+	assert.Equal(t, state.Method, "state")
+
+	// Actions go through the token, and results come back on the stream.
+	id := int64(1)
+	b, err := json.Marshal(&protocol.ChangeHideBombParams{HideBomb: true})
+	assert.NilError(t, err)
+	note := &protocol.ClientNote{ID: &id, Method: protocol.ChangeHideBombMethod, Version: state.Params.RoomState.Version, Params: b}
+
+	assert.Equal(t, room.HandleAction(ctx, "bad", note), ErrInvalidToken)
+	assert.NilError(t, room.HandleAction(ctx, state.Params.Token, note))
+
+	assert.Assert(t, strings.Contains(readEvent(t, body).data, `"hideBomb":true`))
+	assert.Assert(t, strings.Contains(readEvent(t, body).data, `"method":"result"`))
+
+	// Kicked clients get a close event.
+	room.mu.Lock()
+	room.connect("host", "host", newFakeClient(room, "host").client)
+	room.changeHost("host")
+	room.kick(state.Params.PlayerID, statusKicked, "kicked by the host")
+	room.mu.Unlock()
+
+	e := readEvent(t, body)
+	for e.name == "" {
+		e = readEvent(t, body) // States from the host joining.
+	}
+	assert.Equal(t, e.name, "close")
+	assert.Equal(t, e.data, `{"code":4410,"reason":"kicked by the host"}`)
+
+	assert.Equal(t, room.HandleAction(ctx, state.Params.Token, note), ErrNotConnected)
+}
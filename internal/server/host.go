@@ -0,0 +1,199 @@
+package server
+
+import (
+	"strings"
+
+	"github.com/zikaeroh/codies/internal/game"
+	"github.com/zikaeroh/codies/internal/protocol"
+	"nhooyr.io/websocket"
+)
+
+// Close codes sent to clients which are refused or removed by the host.
+const (
+	statusBanned websocket.StatusCode = 4403
+	statusKicked websocket.StatusCode = 4410
+	statusLocked websocket.StatusCode = 4423
+)
+
+// hostMethods may only be used by the host.
+var hostMethods = map[protocol.ClientMethod]bool{
+	protocol.ChangeHostMethod:     true,
+	protocol.KickMethod:           true,
+	protocol.BanMethod:            true,
+	protocol.ChangeLockedMethod:   true,
+	protocol.ChangeHostOnlyMethod: true,
+	protocol.RevokeInvitesMethod:  true,
+}
+
+// settingsMethods may only be used by the host when the room is host only.
+var settingsMethods = map[protocol.ClientMethod]bool{
+	protocol.NewGameMethod:            true,
+	protocol.RandomizeTeamsMethod:     true,
+	protocol.ChangePackMethod:         true,
+	protocol.ChangeTurnModeMethod:     true,
+	protocol.ChangeTurnTimeMethod:     true,
+	protocol.AddPacksMethod:           true,
+	protocol.RemovePackMethod:         true,
+	protocol.ChangeHideBombMethod:     true,
+	protocol.ChangeNumTeamsMethod:     true,
+	protocol.ChangeBoardSizeMethod:    true,
+	protocol.ChangeModeMethod:         true,
+	protocol.ChangeUndoRuleMethod:     true,
+	protocol.ChangeRevealShareMethod:  true,
+	protocol.ChangeStreamSafeMethod:   true,
+	protocol.ChangeHideTeamChatMethod: true,
+}
+
+// Must be called with r.mu locked.
+func (r *Room) allowed(playerID game.PlayerID, method protocol.ClientMethod) bool {
+	if hostMethods[method] || (r.hostOnly && settingsMethods[method]) {
+		return playerID == r.host
+	}
+	return true
+}
+
+// admit checks whether a connection may join the room as the player. If not,
+// it returns the code and reason to close the connection with.
+//
+// Must be called with r.mu locked.
+func (r *Room) admit(playerID game.PlayerID, nickname string, spectator bool) (code websocket.StatusCode, reason string, ok bool) {
+	if r.bannedIDs[playerID] || r.bannedNicknames[strings.ToLower(nickname)] {
+		return statusBanned, "banned from this room", false
+	}
+
+	// Players already in the room may always reconnect, and anyone may watch.
+	if r.locked && !spectator && r.room.Players[playerID] == nil {
+		return statusLocked, "room is locked", false
+	}
+
+	return 0, "", true
+}
+
+// Must be called with r.mu locked.
+func (r *Room) changeHost(playerID game.PlayerID) {
+	if playerID == r.host || r.room.Players[playerID] == nil {
+		return
+	}
+
+	r.host = playerID
+	r.room.Version++
+}
+
+// ensureHost picks a new host if the current one has left the room,
+// preferring connected players.
+//
+// Must be called with r.mu locked.
+func (r *Room) ensureHost() {
+	if r.room.Players[r.host] != nil {
+		return
+	}
+
+	var next game.PlayerID
+
+	for _, members := range r.room.Teams {
+		for _, id := range members {
+			if r.players[id] != nil {
+				r.host = id
+				r.room.Version++
+				return
+			}
+
+			if next == "" {
+				next = id
+			}
+		}
+	}
+
+	r.host = next
+	r.room.Version++
+}
+
+// kick removes a player from the room immediately, closing their connection.
+// They may rejoin unless they are banned or the room is locked.
+//
+// Must be called with r.mu locked.
+func (r *Room) kick(playerID game.PlayerID, code websocket.StatusCode, reason string) {
+	if playerID == r.host || (r.room.Players[playerID] == nil && r.players[playerID] == nil) {
+		return
+	}
+
+	if cl := r.players[playerID]; cl != nil {
+		cl.close(code, reason)
+		delete(r.players, playerID)
+	}
+
+	if t := r.away[playerID]; t != nil {
+		t.Stop()
+		delete(r.away, playerID)
+	}
+
+	delete(r.chatLimits, playerID)
+	r.room.RemovePlayer(playerID)
+	r.room.Version++
+}
+
+// ban prevents a player's reconnect token, a nickname, or both from joining
+// the room, kicking any players in the room who match.
+//
+// Must be called with r.mu locked.
+func (r *Room) ban(playerID game.PlayerID, nickname string) {
+	if playerID == r.host {
+		return
+	}
+
+	if playerID != "" && (r.room.Players[playerID] != nil || r.players[playerID] != nil) {
+		if r.bannedIDs == nil {
+			r.bannedIDs = make(map[game.PlayerID]bool)
+		}
+		r.bannedIDs[playerID] = true
+		r.kick(playerID, statusBanned, "banned from this room")
+	}
+
+	nickname = strings.ToLower(nickname)
+	if nickname == "" {
+		return
+	}
+
+	if host := r.room.Players[r.host]; host != nil && strings.ToLower(host.Nickname) == nickname {
+		return
+	}
+
+	if r.bannedNicknames == nil {
+		r.bannedNicknames = make(map[string]bool)
+	}
+	r.bannedNicknames[nickname] = true
+
+	for id, p := range r.room.Players {
+		if strings.ToLower(p.Nickname) == nickname {
+			r.kick(id, statusBanned, "banned from this room")
+		}
+	}
+
+	for id, cl := range r.players {
+		if cl.spectator && strings.ToLower(cl.nickname) == nickname {
+			r.kick(id, statusBanned, "banned from this room")
+		}
+	}
+
+	r.room.Version++
+}
+
+// Must be called with r.mu locked.
+func (r *Room) changeLocked(locked bool) {
+	if r.locked == locked {
+		return
+	}
+
+	r.locked = locked
+	r.room.Version++
+}
+
+// Must be called with r.mu locked.
+func (r *Room) changeHostOnly(hostOnly bool) {
+	if r.hostOnly == hostOnly {
+		return
+	}
+
+	r.hostOnly = hostOnly
+	r.room.Version++
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/zikaeroh/codies/internal/game"
+	"github.com/zikaeroh/codies/internal/protocol"
+	"gotest.tools/v3/assert"
+	"nhooyr.io/websocket"
+)
+
+type fakeClient struct {
+	*client
+	closed websocket.StatusCode
+}
+
+func newFakeClient(r *Room, playerID game.PlayerID) *fakeClient {
+	f := &fakeClient{}
+	f.client = &client{
+		send:   func(protocol.ServerNote) {},
+		token:  r.newToken(playerID),
+		cancel: func() {},
+		close:  func(code websocket.StatusCode, reason string) { f.closed = code },
+	}
+	return f
+}
+
+func note(t *testing.T, r *Room, method protocol.ClientMethod, params interface{}) *protocol.ClientNote {
+	t.Helper()
+	b, err := json.Marshal(params)
+	assert.NilError(t, err)
+	return &protocol.ClientNote{Method: method, Version: r.room.Version, Params: b}
+}
+
+func TestHost(t *testing.T) {
+	s, stop := runServer(t, nil)
+	defer stop()
+
+	room, err := s.CreateRoom(context.Background(), "", "room", "", false)
+	assert.NilError(t, err)
+
+	ctx := context.Background()
+
+	room.mu.Lock()
+	room.connect("1", "host", newFakeClient(room, "1").client)
+	room.connect("2", "guest", newFakeClient(room, "2").client)
+	assert.Equal(t, room.host, game.PlayerID("1"))
+	room.mu.Unlock()
+
+	// Only the host may make settings host only.
+	assert.NilError(t, room.handleNote(ctx, "2", note(t, room, protocol.ChangeHostOnlyMethod, &protocol.ChangeHostOnlyParams{HostOnly: true})))
+	assert.Assert(t, !room.hostOnly)
+	assert.NilError(t, room.handleNote(ctx, "1", note(t, room, protocol.ChangeHostOnlyMethod, &protocol.ChangeHostOnlyParams{HostOnly: true})))
+	assert.Assert(t, room.hostOnly)
+
+	assert.NilError(t, room.handleNote(ctx, "2", note(t, room, protocol.ChangeHideBombMethod, &protocol.ChangeHideBombParams{HideBomb: true})))
+	assert.Assert(t, !room.hideBomb)
+	assert.NilError(t, room.handleNote(ctx, "1", note(t, room, protocol.ChangeHideBombMethod, &protocol.ChangeHideBombParams{HideBomb: true})))
+	assert.Assert(t, room.hideBomb)
+
+	// Handing off the role.
+	assert.NilError(t, room.handleNote(ctx, "1", note(t, room, protocol.ChangeHostMethod, &protocol.ChangeHostParams{PlayerID: "2"})))
+	assert.Equal(t, room.host, game.PlayerID("2"))
+	assert.Equal(t, room.createRoomState(false, noSide).Host, game.PlayerID("2"))
+}
+
+func TestKickBanLock(t *testing.T) {
+	s, stop := runServer(t, nil)
+	defer stop()
+
+	room, err := s.CreateRoom(context.Background(), "", "room", "", false)
+	assert.NilError(t, err)
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	host := newFakeClient(room, "1")
+	kicked := newFakeClient(room, "2")
+	banned := newFakeClient(room, "3")
+	room.connect("1", "host", host.client)
+	room.connect("2", "kicked", kicked.client)
+	room.connect("3", "Banned", banned.client)
+
+	room.kick("2", statusKicked, "")
+	assert.Equal(t, kicked.closed, statusKicked)
+	assert.Assert(t, room.room.Players["2"] == nil)
+	assert.Assert(t, room.players["2"] == nil)
+
+	// The host can't be kicked.
+	room.kick("1", statusKicked, "")
+	assert.Assert(t, room.room.Players["1"] != nil)
+
+	room.ban("", "banned")
+	assert.Equal(t, banned.closed, statusBanned)
+	assert.Assert(t, room.room.Players["3"] == nil)
+
+	_, _, ok := room.admit("4", "BANNED", false)
+	assert.Assert(t, !ok)
+	_, _, ok = room.admit("2", "kicked", false)
+	assert.Assert(t, ok)
+
+	room.changeLocked(true)
+	code, _, ok := room.admit("4", "new", false)
+	assert.Assert(t, !ok)
+	assert.Equal(t, code, statusLocked)
+	_, _, ok = room.admit("1", "host", false)
+	assert.Assert(t, ok)
+	_, _, ok = room.admit("4", "new", true)
+	assert.Assert(t, ok)
+}
@@ -0,0 +1,205 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/zikaeroh/codies/internal/game"
+	"github.com/zikaeroh/codies/internal/protocol"
+	"github.com/zikaeroh/codies/internal/token"
+)
+
+var (
+	ErrInvalidInvite  = errors.New("server: invalid, expired, or revoked invite")
+	ErrTooManyInvites = errors.New("server: too many invites")
+	ErrNotHost        = errors.New("server: not the host")
+)
+
+// maxInvites is the most invites a room may have outstanding.
+const maxInvites = 100
+
+// inviteClaims is the payload of an invite token.
+type inviteClaims struct {
+	RoomID   string `json:"room"`
+	InviteID int64  `json:"invite"`
+	Expires  int64  `json:"exp"` // Unix time.
+}
+
+// Invite is an invite which hasn't been revoked or used up. Invites are kept
+// in room snapshots, so that their tokens work across restarts.
+type Invite struct {
+	ID        int64      `json:"id"`
+	Expires   time.Time  `json:"expires"`
+	SingleUse bool       `json:"singleUse"`
+	Team      *game.Team `json:"team"`
+}
+
+// parseInvite returns the claims of an invite token signed by tokens, if it
+// hasn't expired. It doesn't check whether the invite has been revoked.
+func parseInvite(tokens *token.Signer, tok string) (*inviteClaims, bool) {
+	if tok == "" {
+		return nil, false
+	}
+
+	payload, err := tokens.Verify(tok)
+	if err != nil {
+		return nil, false
+	}
+
+	var claims inviteClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, false
+	}
+
+	// Reconnect tokens are signed with the same key, but have no invite ID.
+	if claims.RoomID == "" || claims.InviteID == 0 || time.Now().Unix() >= claims.Expires {
+		return nil, false
+	}
+
+	return &claims, true
+}
+
+// FindInvite returns the room an invite token is for, or nil if the invite
+// is invalid, expired, or revoked.
+func (s *Server) FindInvite(tok string) *Room {
+	<-s.ready
+
+	claims, ok := parseInvite(s.tokens, tok)
+	if !ok {
+		return nil
+	}
+
+	room := s.FindRoomByID(claims.RoomID)
+	if room == nil {
+		return nil
+	}
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	if room.lookupInvite(claims) == nil {
+		return nil
+	}
+	return room
+}
+
+// JoinInvite finds the room an invite token is for, counting failed attempts
+// against the client's address like JoinRoom. Single use invites aren't used
+// up until the client connects with them.
+func (s *Server) JoinInvite(addr, tok string) (*Room, error) {
+	if !s.startJoin(addr) {
+		return nil, ErrTooManyFailedJoins
+	}
+
+	room := s.FindInvite(tok)
+	if room == nil {
+		return nil, ErrInvalidInvite
+	}
+
+	s.finishJoin(addr)
+	return room, nil
+}
+
+// CreateInvite creates an invite to the room on behalf of the connected host
+// with the reconnect token, returning the invite's token and when it expires.
+func (r *Room) CreateInvite(tok string, req *protocol.InviteRequest) (string, time.Time, error) {
+	playerID, ok := r.verifyToken(tok)
+	if !ok {
+		return "", time.Time{}, ErrInvalidToken
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.players[playerID] == nil {
+		return "", time.Time{}, ErrNotConnected
+	}
+
+	r.ensureHost()
+	if playerID != r.host {
+		return "", time.Time{}, ErrNotHost
+	}
+
+	if req.Team != nil && (*req.Team < 0 || int(*req.Team) >= len(r.room.Teams)) {
+		return "", time.Time{}, game.ErrInvalidTeam
+	}
+
+	r.pruneInvites()
+	if len(r.invites) >= maxInvites {
+		return "", time.Time{}, ErrTooManyInvites
+	}
+
+	age := r.config.MaxInviteAge
+	if d := time.Duration(req.Expires) * time.Second; d > 0 && d < age {
+		age = d
+	}
+
+	r.inviteID++
+	inv := &Invite{
+		ID:        r.inviteID,
+		Expires:   time.Now().Add(age).Truncate(time.Second),
+		SingleUse: req.SingleUse,
+		Team:      req.Team,
+	}
+
+	if r.invites == nil {
+		r.invites = make(map[int64]*Invite)
+	}
+	r.invites[inv.ID] = inv
+	r.room.Version++
+
+	payload, err := json.Marshal(&inviteClaims{RoomID: r.ID, InviteID: inv.ID, Expires: inv.Expires.Unix()})
+	if err != nil {
+		panic(err)
+	}
+	return r.tokens.Sign(payload), inv.Expires, nil
+}
+
+// lookupInvite returns the outstanding invite the claims are for, or nil if
+// it has been revoked, used up, or is for another room.
+//
+// Must be called with r.mu locked.
+func (r *Room) lookupInvite(claims *inviteClaims) *Invite {
+	if claims.RoomID != r.ID {
+		return nil
+	}
+
+	inv := r.invites[claims.InviteID]
+	if inv == nil || !time.Now().Before(inv.Expires) {
+		return nil
+	}
+	return inv
+}
+
+// useInvite marks an invite as used by a player it has admitted, removing it
+// if it's single use.
+//
+// Must be called with r.mu locked.
+func (r *Room) useInvite(inv *Invite) {
+	if inv.SingleUse {
+		delete(r.invites, inv.ID)
+		r.room.Version++
+	}
+}
+
+// Must be called with r.mu locked.
+func (r *Room) pruneInvites() {
+	now := time.Now()
+	for id, inv := range r.invites {
+		if !now.Before(inv.Expires) {
+			delete(r.invites, id)
+			r.room.Version++
+		}
+	}
+}
+
+// Must be called with r.mu locked.
+func (r *Room) revokeInvites() {
+	if len(r.invites) == 0 {
+		return
+	}
+
+	r.invites = nil
+	r.room.Version++
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zikaeroh/codies/internal/game"
+	"github.com/zikaeroh/codies/internal/protocol"
+	"gotest.tools/v3/assert"
+	"nhooyr.io/websocket"
+)
+
+func TestInvites(t *testing.T) {
+	s, stop := runServer(t, nil)
+	defer stop()
+
+	room, err := s.CreateRoom(context.Background(), "", "room", "pass", false)
+	assert.NilError(t, err)
+
+	ctx := context.Background()
+
+	host := newFakeClient(room, "1")
+	room.mu.Lock()
+	room.connect("1", "host", host.client)
+	room.mu.Unlock()
+
+	_, _, err = room.CreateInvite("bad", &protocol.InviteRequest{})
+	assert.Equal(t, err, ErrInvalidToken)
+
+	_, _, err = room.CreateInvite(room.newToken("2"), &protocol.InviteRequest{})
+	assert.Equal(t, err, ErrNotConnected)
+
+	badTeam := game.Team(2)
+	_, _, err = room.CreateInvite(host.token, &protocol.InviteRequest{Team: &badTeam})
+	assert.Equal(t, err, game.ErrInvalidTeam)
+
+	team := game.Team(1)
+	tok, expires, err := room.CreateInvite(host.token, &protocol.InviteRequest{Expires: 60, SingleUse: true, Team: &team})
+	assert.NilError(t, err)
+	assert.Assert(t, time.Until(expires) <= time.Minute)
+	assert.Equal(t, room.createRoomState(false, noSide).Invites, 1)
+
+	// Reconnect tokens aren't invites.
+	assert.Assert(t, s.FindInvite(host.token) == nil)
+	assert.Assert(t, s.FindInvite(tok+"x") == nil)
+
+	joined, err := s.JoinInvite("a", tok)
+	assert.NilError(t, err)
+	assert.Equal(t, joined, room)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		query := r.URL.Query()
+		room.HandleConn(r.Context(), &protocol.WSQuery{
+			RoomID:   room.ID,
+			Nickname: "guest",
+			Invite:   query.Get("invite"),
+			Key:      query.Get("key"),
+		}, c)
+	}))
+	defer ts.Close()
+
+	dialQuery := func(query url.Values) *websocket.Conn {
+		t.Helper()
+		u := "ws" + strings.TrimPrefix(ts.URL, "http") + "?" + query.Encode()
+		c, _, err := websocket.Dial(ctx, u, nil)
+		assert.NilError(t, err)
+		c.SetReadLimit(1 << 20)
+		return c
+	}
+
+	dial := func(tok string) *websocket.Conn {
+		t.Helper()
+		return dialQuery(url.Values{"invite": {tok}})
+	}
+
+	// The room ID alone doesn't let new players in; invites reveal it.
+	c := dialQuery(url.Values{})
+	_, _, err = c.Read(ctx)
+	assert.Equal(t, websocket.CloseStatus(err), statusUnauthorized)
+
+	c = dialQuery(url.Values{"key": {host.token}})
+	_, _, err = c.Read(ctx)
+	assert.Equal(t, websocket.CloseStatus(err), statusUnauthorized)
+
+	c = dialQuery(url.Values{"key": {room.Key()}})
+	_, _, err = c.Read(ctx)
+	assert.NilError(t, err)
+	c.Close(websocket.StatusNormalClosure, "")
+
+	// Joining with the invite puts the player on its team, and uses it up.
+	c = dial(tok)
+	defer c.Close(websocket.StatusNormalClosure, "")
+
+	_, b, err := c.Read(ctx)
+	assert.NilError(t, err)
+	var state struct {
+		Params *protocol.State `json:"params"`
+	}
+	assert.NilError(t, json.Unmarshal(b, &state))
+
+	room.mu.Lock()
+	assert.Equal(t, room.room.Players[state.Params.PlayerID].Team, team)
+	assert.Equal(t, len(room.invites), 0)
+	room.mu.Unlock()
+
+	assert.Assert(t, s.FindInvite(tok) == nil)
+
+	c = dial(tok)
+	_, _, err = c.Read(ctx)
+	assert.Equal(t, websocket.CloseStatus(err), statusUnauthorized)
+
+	// Only the host may create or revoke invites.
+	tok, _, err = room.CreateInvite(host.token, &protocol.InviteRequest{})
+	assert.NilError(t, err)
+
+	room.mu.Lock()
+	room.connect("3", "guest", newFakeClient(room, "3").client)
+	room.mu.Unlock()
+
+	_, _, err = room.CreateInvite(room.newToken("3"), &protocol.InviteRequest{})
+	assert.Equal(t, err, ErrNotHost)
+
+	assert.NilError(t, room.handleNote(ctx, "3", note(t, room, protocol.RevokeInvitesMethod, &protocol.RevokeInvitesParams{})))
+	assert.Assert(t, s.FindInvite(tok) != nil)
+	assert.NilError(t, room.handleNote(ctx, "1", note(t, room, protocol.RevokeInvitesMethod, &protocol.RevokeInvitesParams{})))
+	assert.Assert(t, s.FindInvite(tok) == nil)
+
+	_, err = s.JoinInvite("a", tok)
+	assert.Equal(t, err, ErrInvalidInvite)
+
+	// Expired invites are removed once another is made.
+	_, _, err = room.CreateInvite(host.token, &protocol.InviteRequest{})
+	assert.NilError(t, err)
+
+	room.mu.Lock()
+	for _, inv := range room.invites {
+		inv.Expires = time.Now()
+	}
+	room.mu.Unlock()
+
+	_, _, err = room.CreateInvite(host.token, &protocol.InviteRequest{})
+	assert.NilError(t, err)
+	assert.Equal(t, room.createRoomState(false, noSide).Invites, 1)
+}
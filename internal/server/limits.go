@@ -0,0 +1,113 @@
+package server
+
+import (
+	"errors"
+	"time"
+
+	"github.com/zikaeroh/codies/internal/game"
+	"github.com/zikaeroh/codies/internal/protocol"
+	"golang.org/x/time/rate"
+	"nhooyr.io/websocket"
+)
+
+// statusRateLimited closes connections which send notes too quickly. Notes
+// which are too large close the connection with websocket.StatusMessageTooBig.
+const statusRateLimited websocket.StatusCode = 4429
+
+var ErrRateLimited = errors.New("server: rate limited")
+
+// noteLimit is a token bucket shared by a class of methods: each connection
+// may send a burst of notes, then one every interval.
+type noteLimit struct {
+	burst    int
+	interval time.Duration
+}
+
+var (
+	// Moves, chat and most other notes.
+	defaultLimit = &noteLimit{burst: 20, interval: 100 * time.Millisecond}
+
+	// Settings change the room for everyone, and may deal a new board.
+	settingsLimit = &noteLimit{burst: 10, interval: 500 * time.Millisecond}
+
+	// Packs are large.
+	packsLimit = &noteLimit{burst: 3, interval: 10 * time.Second}
+
+	// Acks asking for a full state, which is far larger than the ack. Acks
+	// themselves are sent for each patch, so aren't limited.
+	resetLimit = &noteLimit{burst: 5, interval: time.Second}
+)
+
+// noteLimits maps methods to their limits; others use defaultLimit.
+var noteLimits = map[protocol.ClientMethod]*noteLimit{
+	protocol.AckMethod: nil,
+
+	protocol.NewGameMethod:            settingsLimit,
+	protocol.RandomizeTeamsMethod:     settingsLimit,
+	protocol.ChangePackMethod:         settingsLimit,
+	protocol.ChangeTurnModeMethod:     settingsLimit,
+	protocol.ChangeTurnTimeMethod:     settingsLimit,
+	protocol.ChangeHideBombMethod:     settingsLimit,
+	protocol.ChangeNumTeamsMethod:     settingsLimit,
+	protocol.ChangeBoardSizeMethod:    settingsLimit,
+	protocol.ChangeModeMethod:         settingsLimit,
+	protocol.ChangeUndoRuleMethod:     settingsLimit,
+	protocol.ChangeRevealShareMethod:  settingsLimit,
+	protocol.ChangeHideTeamChatMethod: settingsLimit,
+	protocol.ChangeStreamSafeMethod:   settingsLimit,
+	protocol.ChangeHostMethod:         settingsLimit,
+	protocol.KickMethod:               settingsLimit,
+	protocol.BanMethod:                settingsLimit,
+	protocol.ChangeLockedMethod:       settingsLimit,
+	protocol.ChangeHostOnlyMethod:     settingsLimit,
+
+	protocol.AddPacksMethod:   packsLimit,
+	protocol.RemovePackMethod: packsLimit,
+}
+
+// allowNote reports whether a player's connection may send a note with the
+// method. Connections which go over a limit are closed, and their later notes
+// are dropped.
+func (r *Room) allowNote(playerID game.PlayerID, method protocol.ClientMethod) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cl := r.players[playerID]
+	if cl == nil {
+		return true // Reported by handleNote.
+	}
+
+	if cl.limited {
+		return false
+	}
+
+	limit, ok := noteLimits[method]
+	if !ok {
+		limit = defaultLimit
+	}
+	if limit == nil {
+		return true
+	}
+
+	if cl.limiter(limit).Allow() {
+		return true
+	}
+
+	cl.limited = true
+	metricLimited.WithLabelValues("rate").Inc()
+	cl.close(statusRateLimited, "too many messages")
+	return false
+}
+
+// limiter returns the connection's token bucket for a limit.
+func (cl *client) limiter(limit *noteLimit) *rate.Limiter {
+	l := cl.limiters[limit]
+	if l == nil {
+		if cl.limiters == nil {
+			cl.limiters = make(map[*noteLimit]*rate.Limiter)
+		}
+		l = rate.NewLimiter(rate.Every(limit.interval), limit.burst)
+		cl.limiters[limit] = l
+	}
+	return l
+}
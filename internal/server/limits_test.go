@@ -0,0 +1,89 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zikaeroh/codies/internal/protocol"
+	"gotest.tools/v3/assert"
+	"nhooyr.io/websocket"
+)
+
+func TestRateLimit(t *testing.T) {
+	s, stop := runServer(t, nil)
+	defer stop()
+
+	room, err := s.CreateRoom(context.Background(), "", "room", "", false)
+	assert.NilError(t, err)
+
+	ctx := context.Background()
+
+	var states int
+	cl := newFakeClient(room, "1")
+	cl.send = func(protocol.ServerNote) { states++ }
+	cl.patches = newPatcher()
+	room.mu.Lock()
+	room.connect("1", "player", cl.client)
+	room.mu.Unlock()
+
+	// Acks are never limited, but full states asked for with them are.
+	states = 0
+	for i := 0; i < 2*defaultLimit.burst; i++ {
+		assert.NilError(t, room.receive(ctx, "1", note(t, room, protocol.AckMethod, &protocol.AckParams{Version: -1})))
+	}
+	assert.Equal(t, cl.closed, websocket.StatusCode(0))
+	assert.Equal(t, states, resetLimit.burst)
+
+	// Classes have their own buckets.
+	for i := 0; i < packsLimit.burst; i++ {
+		assert.NilError(t, room.receive(ctx, "1", note(t, room, protocol.RemovePackMethod, &protocol.RemovePackParams{Num: 0})))
+	}
+	assert.NilError(t, room.receive(ctx, "1", note(t, room, protocol.ChangeHideBombMethod, &protocol.ChangeHideBombParams{HideBomb: true})))
+	assert.Assert(t, room.hideBomb)
+
+	err = room.receive(ctx, "1", note(t, room, protocol.RemovePackMethod, &protocol.RemovePackParams{Num: 0}))
+	assert.Equal(t, err, ErrRateLimited)
+	assert.Equal(t, cl.closed, statusRateLimited)
+
+	// Once over a limit, everything is dropped.
+	err = room.receive(ctx, "1", note(t, room, protocol.ChangeHideBombMethod, &protocol.ChangeHideBombParams{HideBomb: false}))
+	assert.Equal(t, err, ErrRateLimited)
+	assert.Assert(t, room.hideBomb)
+}
+
+func TestMessageTooBig(t *testing.T) {
+	s, stop := runServer(t, nil)
+	defer stop()
+
+	room, err := s.CreateRoom(context.Background(), "", "room", "", false)
+	assert.NilError(t, err)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		room.HandleConn(r.Context(), &protocol.WSQuery{RoomID: room.ID, Key: room.Key(), Nickname: "player"}, c)
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	c, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(ts.URL, "http"), nil)
+	assert.NilError(t, err)
+	defer c.Close(websocket.StatusNormalClosure, "")
+	c.SetReadLimit(1 << 20)
+
+	params := `{"packs":[{"name":"big","words":["` + strings.Repeat("a", protocol.MaxNoteSize) + `"]}]}`
+	assert.NilError(t, c.Write(ctx, websocket.MessageText, []byte(`{"method":"addPacks","version":0,"params":`+params+`}`)))
+
+	for {
+		_, _, err := c.Read(ctx)
+		if err != nil {
+			assert.Equal(t, websocket.CloseStatus(err), websocket.StatusMessageTooBig)
+			return
+		}
+	}
+}
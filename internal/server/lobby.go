@@ -0,0 +1,84 @@
+package server
+
+import (
+	"sort"
+
+	"github.com/zikaeroh/codies/internal/game"
+	"github.com/zikaeroh/codies/internal/protocol"
+)
+
+// PublicRooms lists the public rooms, those with the most players first, from
+// offset up to limit rooms, along with the total number of public rooms.
+func (s *Server) PublicRooms(offset, limit int) (rooms []*protocol.LobbyRoom, total int) {
+	<-s.ready
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all := make([]*protocol.LobbyRoom, 0)
+	for _, room := range s.rooms {
+		if room.Public {
+			all = append(all, room.lobbyRoom())
+		}
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Players != all[j].Players {
+			return all[i].Players > all[j].Players
+		}
+		return all[i].Name < all[j].Name
+	})
+
+	total = len(all)
+	if offset < 0 || offset > total {
+		offset = total
+	}
+	if limit < 0 || offset+limit > total {
+		limit = total - offset
+	}
+	return all[offset : offset+limit], total
+}
+
+func (r *Room) lobbyRoom() *protocol.LobbyRoom {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lr := &protocol.LobbyRoom{
+		ID:         r.ID,
+		Name:       r.Name,
+		Teams:      make([]int, len(r.room.Teams)),
+		Mode:       r.room.Mode,
+		InProgress: !r.room.Over() && gameStarted(r.room),
+		Packs:      make([]string, 0, len(r.room.WordLists)),
+	}
+
+	for team, members := range r.room.Teams {
+		lr.Teams[team] = len(members)
+		lr.Players += len(members)
+	}
+
+	for _, cl := range r.players {
+		if cl.spectator {
+			lr.Spectators++
+		}
+	}
+
+	for _, list := range r.room.WordLists {
+		if list.Enabled {
+			lr.Packs = append(lr.Packs, list.Name)
+		}
+	}
+
+	return lr
+}
+
+// gameStarted returns true if a move has been made in the current game.
+func gameStarted(r *game.Room) bool {
+	for _, e := range r.Log {
+		switch e.Type {
+		case game.EventReveal, game.EventClue, game.EventEndTurn:
+			return true
+		}
+	}
+	return false
+}
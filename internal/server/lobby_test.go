@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zikaeroh/codies/internal/game"
+	"gotest.tools/v3/assert"
+)
+
+func TestPublicRooms(t *testing.T) {
+	s, stop := runServer(t, nil)
+	defer stop()
+
+	ctx := context.Background()
+
+	quiet, err := s.CreateRoom(ctx, "", "quiet", "", true)
+	assert.NilError(t, err)
+	busy, err := s.CreateRoom(ctx, "", "busy", "", true)
+	assert.NilError(t, err)
+	_, err = s.CreateRoom(ctx, "", "private", "pass", false)
+	assert.NilError(t, err)
+
+	busy.mu.Lock()
+	busy.connect("1", "one", newFakeClient(busy, "1").client)
+	busy.connect("2", "two", newFakeClient(busy, "2").client)
+	busy.connect("3", "three", newFakeClient(busy, "3").client)
+	busy.changeSpectator("3", busy.players["3"], true)
+	busy.room.ForceEndTurn()
+	busy.mu.Unlock()
+
+	rooms, total := s.PublicRooms(0, 10)
+	assert.Equal(t, total, 2)
+	assert.Equal(t, len(rooms), 2)
+
+	r := rooms[0]
+	assert.Equal(t, r.ID, busy.ID)
+	assert.Equal(t, r.Players, 2)
+	assert.DeepEqual(t, r.Teams, []int{1, 1})
+	assert.Equal(t, r.Spectators, 1)
+	assert.Equal(t, r.Mode, game.ModeClassic)
+	assert.Assert(t, r.InProgress)
+	assert.DeepEqual(t, r.Packs, []string{"Base"})
+
+	assert.Equal(t, rooms[1].ID, quiet.ID)
+	assert.Assert(t, !rooms[1].InProgress)
+
+	rooms, total = s.PublicRooms(1, 10)
+	assert.Equal(t, total, 2)
+	assert.Equal(t, len(rooms), 1)
+	assert.Equal(t, rooms[0].ID, quiet.ID)
+
+	rooms, _ = s.PublicRooms(5, 10)
+	assert.Equal(t, len(rooms), 0)
+
+	// Public rooms don't need their password.
+	joined, err := s.JoinRoom("a", "quiet", "")
+	assert.NilError(t, err)
+	assert.Equal(t, joined, quiet)
+
+	_, err = s.JoinRoom("a", "private", "")
+	assert.Equal(t, err, ErrWrongPassword)
+}
@@ -40,4 +40,11 @@ var (
 		Name:      "handle_error_total",
 		Help:      "Total number of handle errors.",
 	})
+
+	metricLimited = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "codies",
+		Subsystem: "codies",
+		Name:      "limited_total",
+		Help:      "Total number of clients disconnected for going over a limit.",
+	}, []string{"limit"})
 )
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"nhooyr.io/websocket"
+)
+
+var (
+	ErrWrongPassword      = errors.New("server: room not found or wrong password")
+	ErrTooManyFailedJoins = errors.New("server: too many failed joins")
+)
+
+// statusUnauthorized closes connections by new players to a private room
+// which have neither its key nor a usable invite.
+const statusUnauthorized websocket.StatusCode = 4401
+
+// passwordCost is the bcrypt cost of room passwords.
+var passwordCost = bcrypt.DefaultCost
+
+// failedJoins counts the failed attempts to join rooms, and the rooms created,
+// from an address.
+type failedJoins struct {
+	count int
+	start time.Time // When the first counted attempt was made.
+}
+
+func hashPassword(password string) ([]byte, error) {
+	return bcrypt.GenerateFromPassword([]byte(password), passwordCost)
+}
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     []byte
+)
+
+// checkDummyPassword takes as long as checking a room's password, so that
+// joins of rooms which don't exist can't be told apart by their timing.
+func checkDummyPassword(password string) {
+	dummyHashOnce.Do(func() {
+		var err error
+		dummyHash, err = hashPassword("")
+		if err != nil {
+			panic(err)
+		}
+	})
+	_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+}
+
+// CheckPassword reports whether password is the room's password.
+func (r *Room) CheckPassword(password string) bool {
+	return bcrypt.CompareHashAndPassword(r.passwordHash, []byte(password)) == nil
+}
+
+// keyClaims is the payload of a room key.
+type keyClaims struct {
+	RoomID string `json:"room"`
+	Key    bool   `json:"key"`
+}
+
+// Key returns the room's key, which is given to clients that created the room
+// or gave its password, and which new players must connect with. The room ID
+// isn't enough, as invites and reconnect tokens reveal it.
+func (r *Room) Key() string {
+	payload, err := json.Marshal(&keyClaims{RoomID: r.ID, Key: true})
+	if err != nil {
+		panic(err)
+	}
+	return r.tokens.Sign(payload)
+}
+
+// verifyKey reports whether tok is the room's key.
+func (r *Room) verifyKey(tok string) bool {
+	if tok == "" {
+		return false
+	}
+
+	payload, err := r.tokens.Verify(tok)
+	if err != nil {
+		return false
+	}
+
+	var claims keyClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return false
+	}
+
+	return claims.RoomID == r.ID && claims.Key
+}
+
+// JoinRoom finds a room by name, checking its password unless the room is
+// public. Failed attempts are counted against the client's address; once
+// there are too many, its attempts are refused until the window they were
+// counted in has passed.
+func (s *Server) JoinRoom(addr, name, password string) (*Room, error) {
+	// Attempts are counted as failures until they succeed, so that a client
+	// can't make many attempts at once.
+	if !s.startJoin(addr) {
+		return nil, ErrTooManyFailedJoins
+	}
+
+	room := s.FindRoom(name)
+	if room == nil {
+		checkDummyPassword(password)
+		return nil, ErrWrongPassword
+	}
+
+	if !room.Public && !room.CheckPassword(password) {
+		return nil, ErrWrongPassword
+	}
+
+	s.finishJoin(addr)
+	return room, nil
+}
+
+func (s *Server) startJoin(addr string) bool {
+	s.joinMu.Lock()
+	defer s.joinMu.Unlock()
+
+	f := s.failedJoins[addr]
+	if f == nil || time.Since(f.start) > s.config.FailedJoinWindow {
+		f = &failedJoins{start: time.Now()}
+		s.failedJoins[addr] = f
+	}
+
+	if f.count >= s.config.MaxFailedJoins {
+		return false
+	}
+
+	f.count++
+	return true
+}
+
+func (s *Server) finishJoin(addr string) {
+	s.joinMu.Lock()
+	defer s.joinMu.Unlock()
+
+	if f := s.failedJoins[addr]; f != nil && f.count > 0 {
+		f.count--
+	}
+}
+
+func (s *Server) pruneFailedJoins() {
+	s.joinMu.Lock()
+	defer s.joinMu.Unlock()
+
+	for addr, f := range s.failedJoins {
+		if time.Since(f.start) > s.config.FailedJoinWindow {
+			delete(s.failedJoins, addr)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+	"gotest.tools/v3/assert"
+)
+
+func init() {
+	// The default cost makes tests which create rooms slow, especially with
+	// the race detector.
+	passwordCost = bcrypt.MinCost
+}
+
+func TestJoinRoom(t *testing.T) {
+	config := DefaultConfig()
+	config.MaxFailedJoins = 3
+
+	s, stop := runServerConfig(t, config, nil)
+	defer stop()
+
+	room, err := s.CreateRoom(context.Background(), "", "room", "pass", false)
+	assert.NilError(t, err)
+	assert.Assert(t, string(room.passwordHash) != "pass")
+
+	joined, err := s.JoinRoom("a", "room", "pass")
+	assert.NilError(t, err)
+	assert.Equal(t, joined, room)
+
+	// Successful joins aren't counted.
+	for i := 0; i < config.MaxFailedJoins; i++ {
+		_, err := s.JoinRoom("a", "room", "wrong")
+		assert.Equal(t, err, ErrWrongPassword)
+	}
+
+	_, err = s.JoinRoom("a", "missing", "pass")
+	assert.Equal(t, err, ErrTooManyFailedJoins)
+	_, err = s.JoinRoom("a", "room", "pass")
+	assert.Equal(t, err, ErrTooManyFailedJoins)
+
+	_, err = s.JoinRoom("b", "missing", "pass")
+	assert.Equal(t, err, ErrWrongPassword)
+
+	// Missing rooms cost as much to check as real ones.
+	dummyCost, err := bcrypt.Cost(dummyHash)
+	assert.NilError(t, err)
+	roomCost, err := bcrypt.Cost(room.passwordHash)
+	assert.NilError(t, err)
+	assert.Equal(t, dummyCost, roomCost)
+
+	_, err = s.JoinRoom("b", "room", "pass")
+	assert.NilError(t, err)
+}
+
+func TestCreateRoomLimit(t *testing.T) {
+	config := DefaultConfig()
+	config.MaxFailedJoins = 3
+	config.MaxRooms = 2
+
+	s, stop := runServerConfig(t, config, nil)
+	defer stop()
+
+	ctx := context.Background()
+
+	_, err := s.CreateRoom(ctx, "a", "one", "pass", false)
+	assert.NilError(t, err)
+	_, err = s.CreateRoom(ctx, "a", "one", "pass", false)
+	assert.Equal(t, err, ErrRoomExists)
+	_, err = s.CreateRoom(ctx, "a", "two", "pass", false)
+	assert.NilError(t, err)
+
+	// Creates share the limit on failed joins, as both hash passwords.
+	_, err = s.CreateRoom(ctx, "a", "three", "pass", false)
+	assert.Equal(t, err, ErrTooManyFailedJoins)
+	_, err = s.JoinRoom("a", "one", "pass")
+	assert.Equal(t, err, ErrTooManyFailedJoins)
+
+	_, err = s.CreateRoom(ctx, "b", "three", "pass", false)
+	assert.Equal(t, err, ErrTooManyRooms)
+}
@@ -0,0 +1,218 @@
+package server
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/zikaeroh/codies/internal/game"
+	"github.com/zikaeroh/codies/internal/protocol"
+)
+
+const (
+	// maxPatchLag is how many states may be sent without an ack before the
+	// client is assumed to be lagging and gets a full state instead.
+	maxPatchLag = 8
+
+	// maxPatchSent is how many sent states are kept to patch against, for
+	// acks which arrive late.
+	maxPatchSent = 2 * maxPatchLag
+)
+
+// patcher tracks the states sent to a client which receives patches.
+type patcher struct {
+	base        interface{} // Last acknowledged state, as decoded JSON.
+	baseVersion int
+	unacked     int
+
+	sent map[int]interface{} // Sent states by version.
+}
+
+func newPatcher() *patcher {
+	return &patcher{sent: make(map[int]interface{})}
+}
+
+// next returns the note to send for a state, which is a patch if the client
+// has acknowledged a recent enough state.
+func (p *patcher) next(version int, note protocol.ServerNote, tree interface{}) protocol.ServerNote {
+	p.sent[version] = tree
+	if len(p.sent) > maxPatchSent {
+		oldest := version
+		for v := range p.sent {
+			if v < oldest {
+				oldest = v
+			}
+		}
+		delete(p.sent, oldest)
+	}
+
+	if p.base == nil || p.unacked >= maxPatchLag {
+		return note
+	}
+
+	p.unacked++
+	return protocol.NewPatchNote(p.baseVersion, version, diffJSON(nil, "", p.base, tree))
+}
+
+// ack marks a sent state as applied by the client. If the state is unknown,
+// the client is sent full states until it acknowledges one.
+func (p *patcher) ack(version int) {
+	tree, ok := p.sent[version]
+	if !ok {
+		p.reset()
+		return
+	}
+
+	p.base = tree
+	p.baseVersion = version
+	p.unacked = 0
+
+	for v := range p.sent {
+		if v < version {
+			delete(p.sent, v)
+		}
+	}
+}
+
+func (p *patcher) reset() {
+	p.base = nil
+	p.unacked = 0
+}
+
+// ack handles a client's ack. Acking -1 asks for a full state, for clients
+// which failed to apply a patch. Clients which ask too often get the full
+// state with the next change instead.
+//
+// Must be called with r.mu locked.
+func (r *Room) ack(playerID game.PlayerID, version int) {
+	cl := r.players[playerID]
+	if cl == nil || cl.patches == nil {
+		return
+	}
+
+	if version == -1 {
+		cl.patches.reset()
+		if cl.limiter(resetLimit).Allow() {
+			r.sendOne(playerID, cl)
+		}
+		return
+	}
+
+	cl.patches.ack(version)
+}
+
+// stateTree returns a state note's params as decoded JSON, to be diffed. The
+// room state's tree is cached, as it's shared between clients.
+//
+// Must be called with r.mu locked.
+func (r *Room) stateTree(state *protocol.State) interface{} {
+	if r.state.trees == nil {
+		r.state.trees = make(map[*protocol.RoomState]interface{})
+	}
+
+	roomTree, ok := r.state.trees[state.RoomState]
+	if !ok {
+		roomTree = toTree(state.RoomState)
+		r.state.trees[state.RoomState] = roomTree
+	}
+
+	return map[string]interface{}{
+		"playerID":  string(state.PlayerID),
+		"token":     state.Token,
+		"spectator": state.Spectator,
+		"roomState": roomTree,
+	}
+}
+
+func toTree(v interface{}) interface{} {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+
+	var tree interface{}
+	if err := json.Unmarshal(b, &tree); err != nil {
+		panic(err)
+	}
+	return tree
+}
+
+// diffJSON appends the operations which change a into b, both decoded JSON,
+// at the path. Arrays are patched element by element, adding or removing
+// elements at their end, which suits the state's append-only arrays like the
+// log.
+func diffJSON(ops []*protocol.PatchOp, path string, a, b interface{}) []*protocol.PatchOp {
+	switch a := a.(type) {
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		for _, k := range sortedKeys(a) {
+			p := path + "/" + escapePointer(k)
+			if bv, ok := b[k]; ok {
+				ops = diffJSON(ops, p, a[k], bv)
+			} else {
+				ops = append(ops, &protocol.PatchOp{Op: "remove", Path: p})
+			}
+		}
+
+		for _, k := range sortedKeys(b) {
+			if _, ok := a[k]; !ok {
+				ops = append(ops, &protocol.PatchOp{Op: "add", Path: path + "/" + escapePointer(k), Value: b[k]})
+			}
+		}
+
+		return ops
+
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok {
+			break
+		}
+
+		n := len(a)
+		if len(b) < n {
+			n = len(b)
+		}
+
+		for i := 0; i < n; i++ {
+			ops = diffJSON(ops, path+"/"+strconv.Itoa(i), a[i], b[i])
+		}
+
+		for i := len(a) - 1; i >= n; i-- {
+			ops = append(ops, &protocol.PatchOp{Op: "remove", Path: path + "/" + strconv.Itoa(i)})
+		}
+
+		for i := n; i < len(b); i++ {
+			ops = append(ops, &protocol.PatchOp{Op: "add", Path: path + "/" + strconv.Itoa(i), Value: b[i]})
+		}
+
+		return ops
+
+	default:
+		// Only scalars remain, which are comparable.
+		if a == b {
+			return ops
+		}
+	}
+
+	return append(ops, &protocol.PatchOp{Op: "replace", Path: path, Value: b})
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func escapePointer(s string) string {
+	return pointerEscaper.Replace(s)
+}
@@ -0,0 +1,173 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/zikaeroh/codies/internal/protocol"
+	"gotest.tools/v3/assert"
+)
+
+// applyPatch applies patch operations as a client would.
+func applyPatch(t *testing.T, doc interface{}, ops []*protocol.PatchOp) interface{} {
+	t.Helper()
+
+	for _, op := range ops {
+		doc = toTree(doc) // Copy, so the original is untouched.
+
+		if op.Path == "" {
+			assert.Equal(t, op.Op, "replace")
+			doc = toTree(op.Value)
+			continue
+		}
+
+		parts := strings.Split(op.Path[1:], "/")
+		parent := doc
+		for _, part := range parts[:len(parts)-1] {
+			switch p := parent.(type) {
+			case map[string]interface{}:
+				parent = p[part]
+			case []interface{}:
+				i, err := strconv.Atoi(part)
+				assert.NilError(t, err)
+				parent = p[i]
+			}
+		}
+
+		last := parts[len(parts)-1]
+		value := toTree(op.Value)
+
+		switch p := parent.(type) {
+		case map[string]interface{}:
+			if op.Op == "remove" {
+				delete(p, last)
+			} else {
+				p[last] = value
+			}
+
+		case []interface{}:
+			i, err := strconv.Atoi(last)
+			assert.NilError(t, err)
+
+			// Arrays can't grow or shrink in place, so replace them in their parent.
+			updated := make([]interface{}, 0, len(p)+1)
+			switch op.Op {
+			case "add":
+				updated = append(append(append(updated, p[:i]...), value), p[i:]...)
+			case "remove":
+				updated = append(append(updated, p[:i]...), p[i+1:]...)
+			default:
+				p[i] = value
+				continue
+			}
+
+			parentPath := "/" + strings.Join(parts[:len(parts)-1], "/")
+			if len(parts) == 1 {
+				parentPath = ""
+			}
+			doc = applyPatch(t, doc, []*protocol.PatchOp{{Op: "replace", Path: parentPath, Value: updated}})
+		}
+	}
+
+	return doc
+}
+
+func TestDiffJSON(t *testing.T) {
+	tests := []struct {
+		a, b string
+		ops  int
+	}{
+		{`{"a": 1}`, `{"a": 1}`, 0},
+		{`{"a": 1}`, `{"a": 2}`, 1},
+		{`{"a": 1}`, `{"b": 1}`, 2},
+		{`{"a": [1, 2]}`, `{"a": [1, 2, 3, 4]}`, 2},
+		{`{"a": [1, 2, 3]}`, `{"a": [1]}`, 2},
+		{`{"a": {"b": null}}`, `{"a": {"b": {"c": true}}}`, 1},
+		{`{"a/b": 1, "c~": 2}`, `{"a/b": 3, "c~": 4}`, 2},
+		{`[1]`, `{"a": 1}`, 1},
+	}
+
+	for _, test := range tests {
+		a := decodeJSON(t, test.a)
+		b := decodeJSON(t, test.b)
+		ops := diffJSON(nil, "", a, b)
+		assert.Equal(t, len(ops), test.ops, "%s -> %s", test.a, test.b)
+
+		if !strings.ContainsAny(test.a, "/~") {
+			assert.DeepEqual(t, applyPatch(t, a, ops), b)
+		}
+	}
+}
+
+func decodeJSON(t *testing.T, s string) interface{} {
+	t.Helper()
+	var v interface{}
+	assert.NilError(t, json.Unmarshal([]byte(s), &v))
+	return v
+}
+
+func TestPatches(t *testing.T) {
+	s, stop := runServer(t, nil)
+	defer stop()
+
+	room, err := s.CreateRoom(context.Background(), "", "room", "", false)
+	assert.NilError(t, err)
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	var notes []protocol.ServerNote
+	cl := newFakeClient(room, "1")
+	cl.patches = newPatcher()
+	cl.send = func(n protocol.ServerNote) { notes = append(notes, n) }
+
+	room.connect("1", "player", cl.client)
+	assert.Equal(t, len(notes), 1)
+	assert.Equal(t, notes[0].Method, protocol.ServerMethod("state"))
+	state := toTree(notes[0].Params)
+
+	// Without an ack, full states are sent.
+	room.changeHideBomb(true)
+	room.sendAll()
+	assert.Equal(t, notes[1].Method, protocol.ServerMethod("state"))
+	state = toTree(notes[1].Params)
+
+	room.ack("1", room.room.Version)
+
+	checkPatch := func() {
+		t.Helper()
+		n := notes[len(notes)-1]
+		assert.Equal(t, n.Method, protocol.ServerMethod("patch"))
+
+		patch := n.Params.(*protocol.Patch)
+		assert.Equal(t, patch.To, room.room.Version)
+
+		want := toTree(protocol.NewStateNote("1", cl.token, false, room.createStateFor("1", cl.client)).Params)
+		assert.DeepEqual(t, applyPatch(t, state, patch.Ops), want)
+	}
+
+	// Both projections patch correctly, including a change between them.
+	room.room.ChangeRole("1", true)
+	room.sendAll()
+	checkPatch()
+
+	room.room.ChangeRole("1", false)
+	room.room.ChangeTeam("1", room.room.Turn)
+	room.room.Reveal("1", 0, 0)
+	room.sendAll()
+	checkPatch()
+
+	// Clients which fall behind get full states.
+	for i := 0; i < maxPatchLag; i++ {
+		room.changeHideBomb(i%2 == 0)
+		room.sendAll()
+	}
+	assert.Equal(t, notes[len(notes)-1].Method, protocol.ServerMethod("state"))
+
+	// As do clients which ask for one.
+	room.ack("1", -1)
+	assert.Equal(t, notes[len(notes)-1].Method, protocol.ServerMethod("state"))
+}
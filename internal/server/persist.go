@@ -0,0 +1,221 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/zikaeroh/codies/internal/game"
+	"github.com/zikaeroh/codies/internal/token"
+	"github.com/zikaeroh/codies/internal/uid"
+	"github.com/zikaeroh/ctxlog"
+	"go.uber.org/zap"
+)
+
+var errNoTokenKey = errors.New("server: saved server state has no token key")
+
+// restore restores the server state and all rooms from the store. Rooms which
+// cannot be restored are skipped.
+func (s *Server) restore(ctx context.Context) error {
+	if s.store == nil {
+		return nil
+	}
+
+	state, err := s.store.LoadServerState()
+	if err != nil {
+		return err
+	}
+
+	if state != nil {
+		// Signing with an empty key would let anyone forge tokens.
+		if len(state.TokenKey) == 0 {
+			return errNoTokenKey
+		}
+
+		s.idSalt = state.Salt
+		s.genRoomID = uid.NewGeneratorFrom(state.Salt, state.Last)
+		s.tokenKey = state.TokenKey
+		s.tokens = token.NewSigner(state.TokenKey)
+		s.savedRoomID = state.Last
+	}
+
+	snaps, err := s.store.LoadRooms(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, snap := range snaps {
+		if s.rooms[snap.Name] != nil || s.roomIDs[snap.ID] != nil {
+			ctxlog.Warn(ctx, "skipping duplicate saved room", zap.String("roomName", snap.Name), zap.String("roomID", snap.ID))
+			continue
+		}
+
+		room, err := s.restoreRoom(snap)
+		if err != nil {
+			ctxlog.Error(ctx, "error restoring room", zap.String("roomID", snap.ID), zap.Error(err))
+			continue
+		}
+
+		s.addRoom(room)
+	}
+
+	ctxlog.Info(ctx, "restored rooms", zap.Int("count", len(s.rooms)))
+	return nil
+}
+
+func (s *Server) restoreRoom(snap *RoomSnapshot) (*Room, error) {
+	gameRoom := game.NewRoom(nil)
+	if err := json.Unmarshal(snap.Game, gameRoom); err != nil {
+		return nil, err
+	}
+
+	room := s.newRoom(snap.Name, snap.PasswordHash, snap.ID, uid.NewGeneratorFrom(snap.ID, snap.LastPlayerID), gameRoom)
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	room.Public = snap.Public
+	room.timed = snap.Timed
+	room.turnSeconds = snap.TurnSeconds
+	room.hideBomb = snap.HideBomb
+	room.host = snap.Host
+	room.locked = snap.Locked
+	room.hostOnly = snap.HostOnly
+	room.streamSafe = snap.StreamSafe
+	room.hideTeamChat = snap.HideTeamChat
+	room.chatID = snap.ChatID
+	room.inviteID = snap.InviteID
+
+	for _, id := range snap.BannedIDs {
+		if room.bannedIDs == nil {
+			room.bannedIDs = make(map[game.PlayerID]bool)
+		}
+		room.bannedIDs[id] = true
+	}
+
+	for _, nickname := range snap.BannedNicknames {
+		if room.bannedNicknames == nil {
+			room.bannedNicknames = make(map[string]bool)
+		}
+		room.bannedNicknames[nickname] = true
+	}
+
+	for _, inv := range snap.Invites {
+		if room.invites == nil {
+			room.invites = make(map[int64]*Invite)
+		}
+		room.invites[inv.ID] = inv
+	}
+
+	// Nobody is connected yet; the players from before the restart are away
+	// until they reconnect.
+	for id := range gameRoom.Players {
+		room.startAwayTimer(id)
+	}
+
+	if room.timed {
+		room.startTimer()
+	}
+
+	return room, nil
+}
+
+// save saves the server state and every room which has changed since it was
+// last saved.
+func (s *Server) save(ctx context.Context) {
+	if s.store == nil {
+		return
+	}
+
+	s.mu.Lock()
+	last := s.genRoomID.Last()
+	rooms := make([]*Room, 0, len(s.rooms))
+	for _, room := range s.rooms {
+		rooms = append(rooms, room)
+	}
+	s.mu.Unlock()
+
+	// Save the server state first, so that saved rooms never have IDs which
+	// the generator could produce again.
+	if last != s.savedRoomID {
+		if err := s.store.SaveServerState(&ServerState{Salt: s.idSalt, Last: last, TokenKey: s.tokenKey}); err != nil {
+			ctxlog.Error(ctx, "error saving server state", zap.Error(err))
+			return
+		}
+		s.savedRoomID = last
+	}
+
+	for _, room := range rooms {
+		snap, version, err := room.snapshot()
+		if err != nil {
+			ctxlog.Error(ctx, "error creating room snapshot", zap.String("roomID", room.ID), zap.Error(err))
+			continue
+		}
+
+		if snap == nil {
+			continue
+		}
+
+		if err := s.store.SaveRoom(snap); err != nil {
+			ctxlog.Error(ctx, "error saving room", zap.String("roomID", room.ID), zap.Error(err))
+			continue
+		}
+
+		room.mu.Lock()
+		room.savedVersion = version
+		room.mu.Unlock()
+	}
+}
+
+// snapshot returns a snapshot of the room and the version it was taken at, or
+// nil if the room hasn't changed since it was last saved.
+func (r *Room) snapshot() (*RoomSnapshot, int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	version := r.room.Version
+	if version == r.savedVersion {
+		return nil, version, nil
+	}
+
+	gameJSON, err := json.Marshal(r.room)
+	if err != nil {
+		return nil, version, err
+	}
+
+	snap := &RoomSnapshot{
+		Name:         r.Name,
+		PasswordHash: r.passwordHash,
+		Public:       r.Public,
+		ID:           r.ID,
+		LastPlayerID: r.genPlayerID.Last(),
+		Timed:        r.timed,
+		TurnSeconds:  r.turnSeconds,
+		HideBomb:     r.hideBomb,
+		Game:         gameJSON,
+		Host:         r.host,
+		Locked:       r.locked,
+		HostOnly:     r.hostOnly,
+		StreamSafe:   r.streamSafe,
+		HideTeamChat: r.hideTeamChat,
+		ChatID:       r.chatID,
+		InviteID:     r.inviteID,
+	}
+
+	for id := range r.bannedIDs {
+		snap.BannedIDs = append(snap.BannedIDs, id)
+	}
+
+	for nickname := range r.bannedNicknames {
+		snap.BannedNicknames = append(snap.BannedNicknames, nickname)
+	}
+
+	for _, inv := range r.invites {
+		snap.Invites = append(snap.Invites, inv)
+	}
+
+	return snap, version, nil
+}
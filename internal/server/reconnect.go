@@ -0,0 +1,132 @@
+package server
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/zikaeroh/codies/internal/game"
+)
+
+// reconnectClaims is the payload of a reconnect token.
+type reconnectClaims struct {
+	RoomID   string        `json:"room"`
+	PlayerID game.PlayerID `json:"player"`
+}
+
+// newToken creates a token which allows the player to reconnect to the room
+// as themselves.
+func (r *Room) newToken(playerID game.PlayerID) string {
+	payload, err := json.Marshal(&reconnectClaims{RoomID: r.ID, PlayerID: playerID})
+	if err != nil {
+		panic(err)
+	}
+	return r.tokens.Sign(payload)
+}
+
+// verifyToken returns the player a reconnect token was issued to, if it is a
+// valid token for this room.
+func (r *Room) verifyToken(tok string) (game.PlayerID, bool) {
+	if tok == "" {
+		return "", false
+	}
+
+	payload, err := r.tokens.Verify(tok)
+	if err != nil {
+		return "", false
+	}
+
+	var claims reconnectClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", false
+	}
+
+	if claims.RoomID != r.ID || claims.PlayerID == "" {
+		return "", false
+	}
+
+	return claims.PlayerID, true
+}
+
+// connect attaches a client to a player, replacing any connection they
+// already have. If the player's grace period has passed, they join again as
+// a new player with the same ID. Spectators leave the game if they were in it.
+// Players joining with an invite to a team are moved to it.
+//
+// Must be called with r.mu locked.
+func (r *Room) connect(playerID game.PlayerID, nickname string, cl *client) {
+	if old := r.players[playerID]; old != nil {
+		old.cancel()
+	}
+
+	if t := r.away[playerID]; t != nil {
+		t.Stop()
+		delete(r.away, playerID)
+	}
+
+	r.players[playerID] = cl
+
+	if cl.spectator {
+		r.room.RemovePlayer(playerID)
+	} else {
+		r.room.AddPlayer(playerID, nickname)
+		if cl.team != nil {
+			// The number of teams may have changed since the invite was made.
+			_ = r.room.ChangeTeam(playerID, *cl.team)
+		}
+	}
+
+	r.room.Version++ // The player is no longer away.
+	r.ensureHost()
+	r.sendAll()
+	r.sendChatLog(playerID, cl)
+}
+
+// disconnect detaches a client from its player, who is shown as away until
+// they reconnect or their grace period ends.
+//
+// Must be called with r.mu locked.
+func (r *Room) disconnect(playerID game.PlayerID, cl *client) {
+	if r.players[playerID] != cl {
+		// Replaced by a newer connection.
+		return
+	}
+
+	delete(r.players, playerID)
+	if !cl.spectator {
+		r.startAwayTimer(playerID)
+	}
+	r.room.Version++
+	r.sendAll()
+}
+
+// Must be called with r.mu locked.
+func (r *Room) startAwayTimer(playerID game.PlayerID) {
+	if t := r.away[playerID]; t != nil {
+		t.Stop()
+	}
+	r.away[playerID] = time.AfterFunc(awayGrace, func() { r.awayExpired(playerID) })
+}
+
+func (r *Room) awayExpired(playerID game.PlayerID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.away[playerID] == nil {
+		// Reconnected, or the room was pruned.
+		return
+	}
+
+	delete(r.away, playerID)
+	delete(r.chatLimits, playerID)
+	r.room.RemovePlayer(playerID)
+	r.ensureHost()
+	r.sendAll()
+}
+
+// Must be called with r.mu locked.
+func (r *Room) stopAwayTimers() {
+	for playerID, t := range r.away {
+		t.Stop()
+		delete(r.away, playerID)
+	}
+}
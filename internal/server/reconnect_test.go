@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zikaeroh/codies/internal/game"
+	"github.com/zikaeroh/codies/internal/protocol"
+	"gotest.tools/v3/assert"
+)
+
+func TestReconnectToken(t *testing.T) {
+	s, stop := runServer(t, nil)
+	defer stop()
+
+	room, err := s.CreateRoom(context.Background(), "", "room", "", false)
+	assert.NilError(t, err)
+	other, err := s.CreateRoom(context.Background(), "", "other", "", false)
+	assert.NilError(t, err)
+
+	tok := room.newToken("3")
+
+	playerID, ok := room.verifyToken(tok)
+	assert.Assert(t, ok)
+	assert.Equal(t, playerID, game.PlayerID("3"))
+
+	_, ok = other.verifyToken(tok)
+	assert.Assert(t, !ok)
+
+	_, ok = room.verifyToken(tok + "x")
+	assert.Assert(t, !ok)
+
+	_, ok = room.verifyToken("")
+	assert.Assert(t, !ok)
+}
+
+func TestReconnect(t *testing.T) {
+	s, stop := runServer(t, nil)
+	defer stop()
+
+	room, err := s.CreateRoom(context.Background(), "", "room", "", false)
+	assert.NilError(t, err)
+
+	var last *protocol.State
+	newClient := func() *client {
+		return &client{
+			send:   func(n protocol.ServerNote) { last = n.Params.(*protocol.State) },
+			token:  room.newToken("1"),
+			cancel: func() {},
+		}
+	}
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	first := newClient()
+	room.connect("1", "alice", first)
+	room.room.ChangeRole("1", true)
+	assert.Equal(t, last.Token, first.token)
+
+	room.disconnect("1", first)
+	assert.Assert(t, room.away["1"] != nil)
+	assert.Assert(t, room.room.Players["1"] != nil)
+
+	away := room.createRoomState(false, noSide).Teams[0]
+	assert.Equal(t, len(away), 1)
+	assert.Assert(t, away[0].Away)
+
+	second := newClient()
+	room.connect("1", "alice", second)
+	assert.Assert(t, room.away["1"] == nil)
+	assert.Assert(t, room.room.Players["1"].Spymaster)
+	assert.Assert(t, !last.RoomState.Teams[0][0].Away)
+
+	// A replaced connection closing doesn't disconnect the player.
+	third := newClient()
+	room.connect("1", "alice", third)
+	room.disconnect("1", second)
+	assert.Equal(t, room.players["1"], third)
+	assert.Assert(t, room.away["1"] == nil)
+}
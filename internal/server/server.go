@@ -4,23 +4,33 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
+	"io/ioutil"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/zikaeroh/codies/internal/game"
 	"github.com/zikaeroh/codies/internal/protocol"
+	"github.com/zikaeroh/codies/internal/token"
 	"github.com/zikaeroh/codies/internal/uid"
 	"github.com/zikaeroh/ctxjoin"
 	"github.com/zikaeroh/ctxlog"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
 	"nhooyr.io/websocket"
-	"nhooyr.io/websocket/wsjson"
 )
 
-const maxRooms = 1000
+const (
+	saveInterval = 5 * time.Second
+
+	// awayGrace is how long a disconnected player keeps their seat, waiting
+	// for them to reconnect.
+	awayGrace = 2 * time.Minute
+)
 
 var (
 	ErrRoomExists   = errors.New("server: rooms exist")
@@ -28,12 +38,23 @@ var (
 )
 
 type Server struct {
+	config      Config
 	clientCount atomic.Int64
 	roomCount   atomic.Int64
 	doPrune     chan struct{}
 	ready       chan struct{}
 
+	joinMu      sync.Mutex
+	failedJoins map[string]*failedJoins // By client address.
+
 	genRoomID *uid.Generator
+	idSalt    string
+
+	tokenKey []byte
+	tokens   *token.Signer // Signs reconnect tokens.
+
+	store       RoomStore // May be nil, in which case rooms are only kept in memory.
+	savedRoomID int64     // Last room ID saved to the store; only accessed by Run.
 
 	ctx context.Context
 
@@ -42,13 +63,27 @@ type Server struct {
 	roomIDs map[string]*Room
 }
 
-func NewServer() *Server {
+// NewServer creates a new server with the given limits. If store is not nil,
+// rooms are saved to it and restored from it when the server is run.
+func NewServer(config Config, store RoomStore) *Server {
+	// IDs are only valid for the salt they were generated with; a restored
+	// salt replaces this one.
+	idSalt := salt()
+	tokenKey := token.NewKey()
+
 	return &Server{
-		ready:     make(chan struct{}),
-		doPrune:   make(chan struct{}, 1),
-		genRoomID: uid.NewGenerator(salt()), // IDs are only valid for this server instance; ok to randomize salt.
-		rooms:     make(map[string]*Room),
-		roomIDs:   make(map[string]*Room),
+		config:      config,
+		ready:       make(chan struct{}),
+		doPrune:     make(chan struct{}, 1),
+		genRoomID:   uid.NewGenerator(idSalt),
+		idSalt:      idSalt,
+		tokenKey:    tokenKey,
+		tokens:      token.NewSigner(tokenKey),
+		store:       store,
+		savedRoomID: -1, // Save the initial state, so the token key is kept.
+		rooms:       make(map[string]*Room),
+		roomIDs:     make(map[string]*Room),
+		failedJoins: make(map[string]*failedJoins),
 	}
 }
 
@@ -60,13 +95,21 @@ func salt() string {
 func (s *Server) Run(ctx context.Context) error {
 	s.ctx = ctx
 
+	if err := s.restore(ctx); err != nil {
+		return err
+	}
+
 	close(s.ready)
-	ticker := time.NewTicker(5 * time.Minute)
+	ticker := time.NewTicker(s.config.PruneInterval)
 	defer ticker.Stop()
 
+	saveTicker := time.NewTicker(saveInterval)
+	defer saveTicker.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
+			s.save(ctx)
 			return ctx.Err()
 
 		case <-s.doPrune:
@@ -74,6 +117,9 @@ func (s *Server) Run(ctx context.Context) error {
 
 		case <-ticker.C:
 			s.prune(ctx)
+
+		case <-saveTicker.C:
+			s.save(ctx)
 		}
 	}
 }
@@ -94,49 +140,50 @@ func (s *Server) FindRoomByID(id string) *Room {
 	return s.roomIDs[id]
 }
 
-func (s *Server) CreateRoom(ctx context.Context, name, password string) (*Room, error) {
+// CreateRoom creates a room. Public rooms are listed by PublicRooms, and may
+// be joined without their password. As hashing the password is slow, each
+// creation is counted against the client's address like a failed join.
+func (s *Server) CreateRoom(ctx context.Context, addr, name, password string, public bool) (*Room, error) {
 	<-s.ready
 
+	if !s.startJoin(addr) {
+		return nil, ErrTooManyFailedJoins
+	}
+
 	s.mu.Lock()
-	defer s.mu.Unlock()
+	err := s.checkNewRoom(name)
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
 
-	room := s.rooms[name]
-	if room != nil {
-		return nil, ErrRoomExists
+	// Hashing is slow, so is done before locking.
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(s.rooms) >= maxRooms {
-		return nil, ErrTooManyRooms
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Another room may have been created while hashing.
+	if err := s.checkNewRoom(name); err != nil {
+		return nil, err
 	}
 
 	id, idRaw := s.genRoomID.Next()
 
-	roomCtx, roomCancel := context.WithCancel(s.ctx)
+	room := s.newRoom(name, passwordHash, id, uid.NewGenerator(id), game.NewRoom(nil))
+	room.Public = public
 
-	room = &Room{
-		Name:        name,
-		Password:    password,
-		ID:          id,
-		clientCount: &s.clientCount,
-		roomCount:   &s.roomCount,
-		genPlayerID: uid.NewGenerator(id),
-		ctx:         roomCtx,
-		cancel:      roomCancel,
-		room:        game.NewRoom(nil),
-		players:     make(map[game.PlayerID]noteSender),
-		turnSeconds: 60,
+	if err := room.room.NewGame(""); err != nil {
+		room.cancel()
+		return nil, err
 	}
 
-	room.lastSeen.Store(time.Now())
+	s.addRoom(room)
 
-	room.room.NewGame()
-
-	s.rooms[name] = room
-	s.roomIDs[room.ID] = room
-	s.roomCount.Inc()
-	metricRooms.Inc()
-
-	ctxlog.Info(ctx, "created new room", zap.String("roomName", name), zap.String("roomID", room.ID))
+	ctxlog.Info(ctx, "created new room", zap.String("roomName", name), zap.String("roomID", room.ID), zap.Bool("public", public))
 
 	if idRaw%100 == 0 {
 		s.triggerPrune()
@@ -145,6 +192,55 @@ func (s *Server) CreateRoom(ctx context.Context, name, password string) (*Room,
 	return room, nil
 }
 
+// checkNewRoom returns why a room with the name can't be created, if it can't.
+//
+// Must be called with s.mu locked.
+func (s *Server) checkNewRoom(name string) error {
+	if s.rooms[name] != nil {
+		return ErrRoomExists
+	}
+
+	if len(s.rooms) >= s.config.MaxRooms {
+		return ErrTooManyRooms
+	}
+
+	return nil
+}
+
+func (s *Server) newRoom(name string, passwordHash []byte, id string, genPlayerID *uid.Generator, gameRoom *game.Room) *Room {
+	roomCtx, roomCancel := context.WithCancel(s.ctx)
+	gameRoom.SetMaxPacks(s.config.MaxPacks)
+
+	room := &Room{
+		Name:         name,
+		passwordHash: passwordHash,
+		ID:           id,
+		config:       &s.config,
+		clientCount:  &s.clientCount,
+		roomCount:    &s.roomCount,
+		genPlayerID:  genPlayerID,
+		tokens:       s.tokens,
+		ctx:          roomCtx,
+		cancel:       roomCancel,
+		room:         gameRoom,
+		players:      make(map[game.PlayerID]*client),
+		away:         make(map[game.PlayerID]*time.Timer),
+		savedVersion: -1,
+		turnSeconds:  s.config.TurnSeconds,
+	}
+
+	room.lastSeen.Store(time.Now())
+	return room
+}
+
+// Must be called with s.mu locked.
+func (s *Server) addRoom(room *Room) {
+	s.rooms[room.Name] = room
+	s.roomIDs[room.ID] = room
+	s.roomCount.Inc()
+	metricRooms.Inc()
+}
+
 func (s *Server) triggerPrune() {
 	select {
 	case s.doPrune <- struct{}{}:
@@ -153,6 +249,8 @@ func (s *Server) triggerPrune() {
 }
 
 func (s *Server) prune(ctx context.Context) {
+	s.pruneFailedJoins()
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -160,7 +258,7 @@ func (s *Server) prune(ctx context.Context) {
 
 	for name, room := range s.rooms {
 		lastSeen := room.lastSeen.Load().(time.Time)
-		if time.Since(lastSeen) > 10*time.Minute {
+		if time.Since(lastSeen) > s.config.PruneAfter {
 			toRemove = append(toRemove, name)
 		}
 	}
@@ -173,6 +271,7 @@ func (s *Server) prune(ctx context.Context) {
 		room := s.rooms[name]
 		room.mu.Lock()
 		room.stopTimer()
+		room.stopAwayTimers()
 		room.mu.Unlock()
 
 		room.cancel()
@@ -180,6 +279,12 @@ func (s *Server) prune(ctx context.Context) {
 		delete(s.roomIDs, room.ID)
 		s.roomCount.Dec()
 		metricRooms.Dec()
+
+		if s.store != nil {
+			if err := s.store.DeleteRoom(room.ID); err != nil {
+				ctxlog.Error(ctx, "error deleting saved room", zap.String("roomID", room.ID), zap.Error(err))
+			}
+		}
 	}
 
 	ctxlog.Info(ctx, "pruned rooms", zap.Int("count", len(toRemove)))
@@ -192,21 +297,26 @@ func (s *Server) Stats() (rooms, clients int) {
 }
 
 type Room struct {
-	Name     string
-	Password string
-	ID       string
-
-	ctx         context.Context
-	cancel      context.CancelFunc
-	clientCount *atomic.Int64
-	roomCount   *atomic.Int64
-	genPlayerID *uid.Generator
-
-	mu       sync.Mutex
-	room     *game.Room
-	players  map[game.PlayerID]noteSender
-	state    *stateCache
-	lastSeen atomic.Value
+	Name   string
+	ID     string
+	Public bool
+
+	passwordHash []byte // bcrypt hash.
+	config       *Config
+	ctx          context.Context
+	cancel       context.CancelFunc
+	clientCount  *atomic.Int64
+	roomCount    *atomic.Int64
+	genPlayerID  *uid.Generator
+	tokens       *token.Signer
+
+	mu           sync.Mutex
+	room         *game.Room
+	players      map[game.PlayerID]*client
+	away         map[game.PlayerID]*time.Timer // Grace timers of disconnected players.
+	state        *stateCache
+	lastSeen     atomic.Value
+	savedVersion int // Version of the room last saved to the store.
 
 	timed        bool
 	turnSeconds  int
@@ -214,12 +324,89 @@ type Room struct {
 	turnTimer    *time.Timer
 
 	hideBomb bool
+
+	host       game.PlayerID
+	locked     bool // Only players already in the room may join.
+	hostOnly   bool // Only the host may change settings.
+	streamSafe bool // Spectators never see the key.
+
+	chatLog         []*chatMessage // Scrollback, oldest first.
+	chatID          int64          // ID of the last message.
+	chatLimits      map[game.PlayerID]*rate.Limiter
+	hideTeamChat    bool // Spymasters can't see or send team-only chat.
+	bannedIDs       map[game.PlayerID]bool
+	bannedNicknames map[string]bool // Lowercased.
+
+	invites  map[int64]*Invite // Outstanding invites, by ID.
+	inviteID int64             // ID of the last invite.
 }
 
 type noteSender func(protocol.ServerNote)
 
-func (r *Room) HandleConn(ctx context.Context, nickname string, c *websocket.Conn) {
-	playerID, _ := r.genPlayerID.Next()
+// client is a player's or spectator's connection to the room.
+type client struct {
+	send   noteSender
+	token  string // Reconnect token, sent with each state.
+	cancel context.CancelFunc
+	close  func(code websocket.StatusCode, reason string)
+
+	// Spectators aren't players in the game; they only watch.
+	spectator bool
+	nickname  string     // Only kept for spectators.
+	team      *game.Team // Team to join, from an invite.
+
+	patches *patcher // Set if the client receives patches.
+
+	limiters map[*noteLimit]*rate.Limiter
+	limited  bool // Set once over a limit; the connection is closing.
+}
+
+// transport carries notes between the room and a client.
+type transport interface {
+	// send sends a note to the client. It's called with the room locked, so
+	// must not block.
+	send(ctx context.Context, note protocol.ServerNote)
+
+	// close ends the connection. Like send, it must not block.
+	close(code websocket.StatusCode, reason string)
+
+	// run carries notes until the connection ends or ctx is done, passing
+	// any notes sent by the client to r.receive.
+	run(ctx context.Context, r *Room, playerID game.PlayerID) error
+}
+
+// HandleConn handles a player's WebSocket connection.
+func (r *Room) HandleConn(ctx context.Context, query *protocol.WSQuery, c *websocket.Conn) {
+	// The transport checks the size of notes itself, so that they can be
+	// counted; this limit only needs to be above it.
+	c.SetReadLimit(protocol.MaxNoteSize + 1)
+
+	t := &wsTransport{
+		c:      c,
+		codec:  protocol.CodecFor(c.Subprotocol()),
+		closed: make(chan struct{}),
+	}
+	r.serve(ctx, query, t)
+
+	// A refused client may still be being closed with the reason; don't
+	// replace it.
+	t.close(websocket.StatusGoingAway, "going away")
+	<-t.closed
+}
+
+// serve runs a client's connection. If the query has a reconnect token
+// previously sent to a player of this room, the connection takes over that
+// player's seat; otherwise, a new player joins with the query's invite, or
+// with the room's key unless the room is public. Spectators watch the game
+// without joining it.
+func (r *Room) serve(ctx context.Context, query *protocol.WSQuery, t transport) {
+	nickname := query.Nickname
+	spectator := query.Spectator
+
+	playerID, ok := r.verifyToken(query.Token)
+	if !ok {
+		playerID, _ = r.genPlayerID.Next()
+	}
 
 	ctx, cancel := ctxjoin.AddCancel(ctx, r.ctx)
 	defer cancel()
@@ -237,46 +424,145 @@ func (r *Room) HandleConn(ctx context.Context, nickname string, c *websocket.Con
 		ctxlog.Info(ctx, "client disconnected", zap.Int64("clientCount", clientCount), zap.Int64("roomCount", r.roomCount.Load()))
 	}()
 
-	defer c.Close(websocket.StatusGoingAway, "going away")
+	r.mu.Lock()
+	var inv *Invite
+	if !ok {
+		reason := ""
+		if query.Invite != "" {
+			if claims, valid := parseInvite(r.tokens, query.Invite); valid {
+				inv = r.lookupInvite(claims)
+			}
+			if inv == nil {
+				reason = "invite is invalid, expired, or revoked"
+			}
+		} else if !r.Public && !r.verifyKey(query.Key) {
+			reason = "the room's password or an invite is needed to join"
+		}
 
-	g, ctx := errgroup.WithContext(ctx)
+		if reason != "" {
+			r.mu.Unlock()
+			ctxlog.Info(ctx, "client refused", zap.String("reason", reason))
+			t.close(statusUnauthorized, reason)
+			return
+		}
+	}
 
-	r.mu.Lock()
-	r.players[playerID] = func(s protocol.ServerNote) {
+	if code, reason, ok := r.admit(playerID, nickname, spectator); !ok {
+		r.mu.Unlock()
+		ctxlog.Info(ctx, "client refused", zap.String("reason", reason))
+		t.close(code, reason)
+		return
+	}
+
+	cl := &client{
+		token:     r.newToken(playerID),
+		cancel:    cancel,
+		close:     t.close,
+		spectator: spectator,
+		nickname:  nickname,
+	}
+	if inv != nil {
+		// Only now that the player has been admitted.
+		r.useInvite(inv)
+		cl.team = inv.Team
+	}
+	if query.Patches {
+		cl.patches = newPatcher()
+	}
+	cl.send = func(s protocol.ServerNote) {
 		if ctx.Err() != nil {
 			return
 		}
-
-		// It's not safe to start more group goroutines concurrently; just use a regular
-		// goroutine and hope that errors here will be reflected later via ping/receive failures.
-		go func() {
-			ctx, cancel := context.WithTimeout(ctx, time.Second)
-			defer cancel()
-			if err := wsjson.Write(ctx, c, &s); err != nil {
-				return
-			}
-			metricSent.Inc()
-		}()
+		t.send(ctx, s)
 	}
-	r.room.AddPlayer(playerID, nickname)
-	r.sendAll()
+	r.connect(playerID, nickname, cl)
 	r.mu.Unlock()
 
 	defer func() {
 		r.mu.Lock()
 		defer r.mu.Unlock()
-		delete(r.players, playerID)
-		r.room.RemovePlayer(playerID)
-		r.sendAll()
+		r.disconnect(playerID, cl)
 	}()
 
+	_ = t.run(ctx, r, playerID)
+}
+
+// receive handles a note sent by a connected client.
+func (r *Room) receive(ctx context.Context, playerID game.PlayerID, note *protocol.ClientNote) error {
+	ctx = ctxlog.With(ctx, zap.String("method", string(note.Method)))
+
+	r.lastSeen.Store(time.Now())
+	metricReceived.Inc()
+
+	if !r.allowNote(playerID, note.Method) {
+		ctxlog.Debug(ctx, "note rate limited")
+		return ErrRateLimited
+	}
+
+	if err := r.handleNote(ctx, playerID, note); err != nil {
+		metricHandleErrors.Inc()
+		ctxlog.Error(ctx, "error handling note", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+type wsTransport struct {
+	c     *websocket.Conn
+	codec protocol.Codec
+
+	closeOnce sync.Once
+	closed    chan struct{} // Closed once the close started by close is done.
+}
+
+var _ transport = (*wsTransport)(nil)
+
+func (t *wsTransport) send(ctx context.Context, s protocol.ServerNote) {
+	msgType := websocket.MessageText
+	if t.codec.Binary() {
+		msgType = websocket.MessageBinary
+	}
+
+	// It's not safe to start more group goroutines concurrently; just use a regular
+	// goroutine and hope that errors here will be reflected later via ping/receive failures.
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+
+		b, err := t.codec.MarshalServerNote(&s)
+		if err != nil {
+			ctxlog.Error(ctx, "error encoding note", zap.Error(err))
+			return
+		}
+
+		if err := t.c.Write(ctx, msgType, b); err != nil {
+			return
+		}
+		metricSent.Inc()
+	}()
+}
+
+func (t *wsTransport) close(code websocket.StatusCode, reason string) {
+	// Closing waits for the client, so it must not hold the lock.
+	t.closeOnce.Do(func() {
+		go func() {
+			defer close(t.closed)
+			t.c.Close(code, reason) //nolint:errcheck
+		}()
+	})
+}
+
+func (t *wsTransport) run(ctx context.Context, r *Room, playerID game.PlayerID) error {
+	g, ctx := errgroup.WithContext(ctx)
+
 	g.Go(func() error {
 		<-ctx.Done()
-		return c.Close(websocket.StatusGoingAway, "going away")
+		return t.c.Close(websocket.StatusGoingAway, "going away")
 	})
 
 	g.Go(func() error {
-		ticker := time.NewTicker(time.Minute)
+		ticker := time.NewTicker(r.config.PingInterval)
 		defer ticker.Stop()
 
 		for {
@@ -286,7 +572,7 @@ func (r *Room) HandleConn(ctx context.Context, nickname string, c *websocket.Con
 			case <-ticker.C:
 			}
 
-			if err := c.Ping(ctx); err != nil {
+			if err := t.c.Ping(ctx); err != nil {
 				return err
 			}
 
@@ -296,35 +582,135 @@ func (r *Room) HandleConn(ctx context.Context, nickname string, c *websocket.Con
 
 	g.Go(func() error {
 		for {
-			var note protocol.ClientNote
-
-			if err := wsjson.Read(ctx, c, &note); err != nil {
+			b, err := t.read(ctx)
+			if err != nil {
 				return err
 			}
 
-			ctx := ctxlog.With(ctx, zap.String("method", string(note.Method)))
-
-			r.lastSeen.Store(time.Now())
-			metricReceived.Inc()
+			var note protocol.ClientNote
+			if err := t.codec.UnmarshalClientNote(b, &note); err != nil {
+				return err
+			}
 
-			if err := r.handleNote(ctx, playerID, &note); err != nil {
-				metricHandleErrors.Inc()
-				ctxlog.Error(ctx, "error handling note", zap.Error(err))
+			if err := r.receive(ctx, playerID, &note); err != nil {
+				if err == ErrRateLimited {
+					// The room is closing the connection; keep reading
+					// until the client replies to the close.
+					continue
+				}
 				return err
 			}
 		}
 	})
 
-	_ = g.Wait()
+	return g.Wait()
+}
+
+// read reads a message, closing the connection if it's larger than
+// protocol.MaxNoteSize.
+func (t *wsTransport) read(ctx context.Context) ([]byte, error) {
+	_, rd, err := t.c.Reader(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := ioutil.ReadAll(io.LimitReader(rd, protocol.MaxNoteSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) > protocol.MaxNoteSize {
+		metricLimited.WithLabelValues("size").Inc()
+		_ = t.c.Close(websocket.StatusMessageTooBig, "message too big")
+		return nil, errTooBig
+	}
+
+	return b, nil
 }
 
-var errMissingPlayer = errors.New("missing player during handleNote")
+var (
+	errMissingPlayer = errors.New("missing player during handleNote")
+
+	errTooBig        = errors.New("server: message too big")
+	errStaleVersion  = errors.New("server: stale version")
+	errForbidden     = errors.New("server: forbidden")
+	errInvalidParams = errors.New("server: invalid params")
+)
 
 //nolint:gocyclo
 func (r *Room) handleNote(ctx context.Context, playerID game.PlayerID, note *protocol.ClientNote) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	err := r.applyNote(ctx, playerID, note)
+	if err == errMissingPlayer {
+		return err
+	}
+
+	if err != nil {
+		ctxlog.Debug(ctx, "note rejected", zap.Error(err))
+	}
+
+	if note.ID != nil {
+		if cl := r.players[playerID]; cl != nil {
+			var message string
+			if err != nil {
+				message = err.Error()
+			}
+			cl.send(protocol.NewResultNote(*note.ID, resultCode(err), message))
+		}
+	}
+
+	return nil
+}
+
+// resultCode returns the code sent in reply to a note which failed with the
+// error, or an empty code if it succeeded.
+func resultCode(err error) protocol.ResultCode {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, errStaleVersion):
+		return protocol.ResultStaleVersion
+	case errors.Is(err, game.ErrNotYourTurn):
+		return protocol.ResultNotYourTurn
+	case errors.Is(err, ErrRateLimited):
+		return protocol.ResultRateLimited
+	case errors.Is(err, errForbidden),
+		errors.Is(err, game.ErrNotAllowed),
+		errors.Is(err, game.ErrGameOver),
+		errors.Is(err, game.ErrUnknownPlayer):
+		return protocol.ResultForbidden
+	default:
+		// Everything else, including malformed params, is the client's mistake.
+		return protocol.ResultInvalidParams
+	}
+}
+
+// applyNote applies a note to the room, returning why it had no effect if it
+// was rejected.
+//
+// Must be called with r.mu locked.
+func (r *Room) applyNote(ctx context.Context, playerID game.PlayerID, note *protocol.ClientNote) error {
+	// Chat doesn't depend on the game state, so it's accepted at any version.
+	if note.Method == protocol.ChatMethod {
+		var params protocol.ChatParams
+		if err := json.Unmarshal(note.Params, &params); err != nil {
+			return err
+		}
+		return r.chat(playerID, params.Text, params.Team)
+	}
+
+	// Likewise for acks, which refer to earlier versions.
+	if note.Method == protocol.AckMethod {
+		var params protocol.AckParams
+		if err := json.Unmarshal(note.Params, &params); err != nil {
+			return err
+		}
+		r.ack(playerID, params.Version)
+		return nil
+	}
+
 	// The client's version was wrong; reject and send them the current state.
 	if note.Version != r.room.Version {
 		p := r.players[playerID]
@@ -332,11 +718,20 @@ func (r *Room) handleNote(ctx context.Context, playerID game.PlayerID, note *pro
 			return errMissingPlayer
 		}
 		r.sendOne(playerID, p)
-		return nil
+		return errStaleVersion
+	}
+
+	if cl := r.players[playerID]; cl != nil && cl.spectator && !spectatorMethods[note.Method] {
+		return errForbidden
+	}
+
+	if !r.allowed(playerID, note.Method) {
+		return errForbidden
 	}
 
 	before := r.room.Version
 	resetTimer := false
+	var err error
 
 	defer func() {
 		if r.room.Version != before {
@@ -354,7 +749,7 @@ func (r *Room) handleNote(ctx context.Context, playerID game.PlayerID, note *pro
 			return err
 		}
 		prevTurn := r.room.Turn
-		r.room.Reveal(playerID, params.Row, params.Col)
+		err = r.room.Reveal(playerID, params.Row, params.Col)
 		resetTimer = prevTurn != r.room.Turn
 
 	case protocol.NewGameMethod:
@@ -363,7 +758,16 @@ func (r *Room) handleNote(ctx context.Context, playerID game.PlayerID, note *pro
 			return err
 		}
 		resetTimer = true
-		r.room.NewGame()
+		// If the game can't be started, the version is unchanged and nothing is sent.
+		if params.Seed != nil {
+			seed, perr := strconv.ParseInt(*params.Seed, 10, 64)
+			if perr != nil {
+				return errInvalidParams
+			}
+			err = r.room.NewSeededGame(playerID, seed)
+		} else {
+			err = r.room.NewGame(playerID)
+		}
 
 	case protocol.EndTurnMethod:
 		var params protocol.EndTurnParams
@@ -371,21 +775,21 @@ func (r *Room) handleNote(ctx context.Context, playerID game.PlayerID, note *pro
 			return err
 		}
 		resetTimer = true
-		r.room.EndTurn(playerID)
+		err = r.room.EndTurn(playerID)
 
 	case protocol.RandomizeTeamsMethod:
 		var params protocol.RandomizeTeamsParams
 		if err := json.Unmarshal(note.Params, &params); err != nil {
 			return err
 		}
-		r.room.RandomizeTeams()
+		r.room.RandomizeTeams(playerID)
 
 	case protocol.ChangeTeamMethod:
 		var params protocol.ChangeTeamParams
 		if err := json.Unmarshal(note.Params, &params); err != nil {
 			return err
 		}
-		r.room.ChangeTeam(playerID, params.Team)
+		err = r.room.ChangeTeam(playerID, params.Team)
 
 	case protocol.ChangeNicknameMethod:
 		var params protocol.ChangeNicknameParams
@@ -394,7 +798,16 @@ func (r *Room) handleNote(ctx context.Context, playerID game.PlayerID, note *pro
 		}
 
 		// Sync with protocol.go's validation method.
-		if len(params.Nickname) == 0 || len(params.Nickname) > 16 {
+		if len(params.Nickname) == 0 || len(params.Nickname) > r.config.MaxNickname {
+			return errInvalidParams
+		}
+
+		if r.bannedNicknames[strings.ToLower(params.Nickname)] {
+			return errForbidden
+		}
+
+		if cl := r.players[playerID]; cl != nil && cl.spectator {
+			r.changeSpectatorNickname(cl, params.Nickname)
 			return nil
 		}
 
@@ -405,14 +818,39 @@ func (r *Room) handleNote(ctx context.Context, playerID game.PlayerID, note *pro
 		if err := json.Unmarshal(note.Params, &params); err != nil {
 			return err
 		}
-		r.room.ChangeRole(playerID, params.Spymaster)
+		cl := r.players[playerID]
+		if cl != nil && cl.spectator != params.Spectator {
+			r.changeSpectator(playerID, cl, params.Spectator)
+			if cl.spectator == params.Spectator {
+				return nil
+			}
+		}
+		// Spectators have no role; joining a locked room leaves them watching.
+		if cl == nil || cl.spectator {
+			return errForbidden
+		}
+		err = r.room.ChangeRole(playerID, params.Spymaster)
+
+	case protocol.ChangeStreamSafeMethod:
+		var params protocol.ChangeStreamSafeParams
+		if err := json.Unmarshal(note.Params, &params); err != nil {
+			return err
+		}
+		r.changeStreamSafe(params.StreamSafe)
+
+	case protocol.ChangeHideTeamChatMethod:
+		var params protocol.ChangeHideTeamChatParams
+		if err := json.Unmarshal(note.Params, &params); err != nil {
+			return err
+		}
+		r.changeHideTeamChat(params.HideTeamChat)
 
 	case protocol.ChangePackMethod:
 		var params protocol.ChangePackParams
 		if err := json.Unmarshal(note.Params, &params); err != nil {
 			return err
 		}
-		r.room.ChangePack(params.Num, params.Enable)
+		err = r.room.ChangePack(params.Num, params.Enable)
 
 	case protocol.ChangeTurnModeMethod:
 		var params protocol.ChangeTurnModeParams
@@ -426,6 +864,9 @@ func (r *Room) handleNote(ctx context.Context, playerID game.PlayerID, note *pro
 		if err := json.Unmarshal(note.Params, &params); err != nil {
 			return err
 		}
+		if params.Seconds <= 0 {
+			return errInvalidParams
+		}
 		r.changeTurnTime(params.Seconds)
 
 	case protocol.AddPacksMethod:
@@ -433,11 +874,16 @@ func (r *Room) handleNote(ctx context.Context, playerID game.PlayerID, note *pro
 		if err := json.Unmarshal(note.Params, &params); err != nil {
 			return err
 		}
+		// Valid packs are added even if others are rejected. Each must be able
+		// to fill the board on its own.
 		for _, p := range params.Packs {
-			if len(p.Words) < 25 {
+			if len(p.Words) < r.room.Rows*r.room.Cols {
+				err = errInvalidParams
 				continue
 			}
-			r.room.AddPack(p.Name, p.Words)
+			if addErr := r.room.AddPack(p.Name, p.Words); addErr != nil {
+				err = addErr
+			}
 		}
 
 	case protocol.RemovePackMethod:
@@ -445,7 +891,7 @@ func (r *Room) handleNote(ctx context.Context, playerID game.PlayerID, note *pro
 		if err := json.Unmarshal(note.Params, &params); err != nil {
 			return err
 		}
-		r.room.RemovePack(params.Num)
+		err = r.room.RemovePack(params.Num)
 
 	case protocol.ChangeHideBombMethod:
 		var params protocol.ChangeHideBombParams
@@ -454,39 +900,148 @@ func (r *Room) handleNote(ctx context.Context, playerID game.PlayerID, note *pro
 		}
 		r.changeHideBomb(params.HideBomb)
 
+	case protocol.ChangeNumTeamsMethod:
+		var params protocol.ChangeNumTeamsParams
+		if err := json.Unmarshal(note.Params, &params); err != nil {
+			return err
+		}
+		resetTimer = true
+		err = r.room.ChangeNumTeams(playerID, params.NumTeams)
+
+	case protocol.ChangeBoardSizeMethod:
+		var params protocol.ChangeBoardSizeParams
+		if err := json.Unmarshal(note.Params, &params); err != nil {
+			return err
+		}
+		err = r.room.ChangeBoardSize(params.Rows, params.Cols)
+
+	case protocol.ChangeModeMethod:
+		var params protocol.ChangeModeParams
+		if err := json.Unmarshal(note.Params, &params); err != nil {
+			return err
+		}
+		resetTimer = true
+		err = r.room.ChangeMode(playerID, params.Mode)
+
+	case protocol.GiveClueMethod:
+		var params protocol.GiveClueParams
+		if err := json.Unmarshal(note.Params, &params); err != nil {
+			return err
+		}
+		err = r.room.GiveClue(playerID, params.Word, params.Count, params.Unlimited)
+
+	case protocol.UndoMethod:
+		var params protocol.UndoParams
+		if err := json.Unmarshal(note.Params, &params); err != nil {
+			return err
+		}
+		hadUndo := r.room.Undo != nil
+		err = r.room.VoteUndo(playerID)
+		resetTimer = hadUndo && r.room.Undo == nil
+
+	case protocol.ChangeUndoRuleMethod:
+		var params protocol.ChangeUndoRuleParams
+		if err := json.Unmarshal(note.Params, &params); err != nil {
+			return err
+		}
+		err = r.room.ChangeUndoRule(params.Rule)
+
+	case protocol.ChangeRevealShareMethod:
+		var params protocol.ChangeRevealShareParams
+		if err := json.Unmarshal(note.Params, &params); err != nil {
+			return err
+		}
+		err = r.room.ChangeRevealShare(params.Share)
+
+	case protocol.ChangeHostMethod:
+		var params protocol.ChangeHostParams
+		if err := json.Unmarshal(note.Params, &params); err != nil {
+			return err
+		}
+		if r.room.Players[params.PlayerID] == nil {
+			return errInvalidParams
+		}
+		r.changeHost(params.PlayerID)
+
+	case protocol.KickMethod:
+		var params protocol.KickParams
+		if err := json.Unmarshal(note.Params, &params); err != nil {
+			return err
+		}
+		r.kick(params.PlayerID, statusKicked, "kicked by the host")
+
+	case protocol.BanMethod:
+		var params protocol.BanParams
+		if err := json.Unmarshal(note.Params, &params); err != nil {
+			return err
+		}
+		r.ban(params.PlayerID, params.Nickname)
+
+	case protocol.ChangeLockedMethod:
+		var params protocol.ChangeLockedParams
+		if err := json.Unmarshal(note.Params, &params); err != nil {
+			return err
+		}
+		r.changeLocked(params.Locked)
+
+	case protocol.ChangeHostOnlyMethod:
+		var params protocol.ChangeHostOnlyParams
+		if err := json.Unmarshal(note.Params, &params); err != nil {
+			return err
+		}
+		r.changeHostOnly(params.HostOnly)
+
+	case protocol.RevokeInvitesMethod:
+		r.revokeInvites()
+
 	default:
 		ctxlog.Warn(ctx, "unhandled method")
+		return errInvalidParams
 	}
 
-	return nil
+	return err
 }
 
 // Must be called with r.mu locked.
 func (r *Room) sendAll() {
-	for playerID, sender := range r.players {
-		r.sendOne(playerID, sender)
+	for playerID, cl := range r.players {
+		r.sendOne(playerID, cl)
 	}
 }
 
 // Must be called with r.mu locked.
-func (r *Room) sendOne(playerID game.PlayerID, sender noteSender) {
-	state := r.createStateFor(playerID)
-	note := protocol.NewStateNote(playerID, state)
-	sender(note)
+func (r *Room) sendOne(playerID game.PlayerID, cl *client) {
+	state := r.createStateFor(playerID, cl)
+	note := protocol.NewStateNote(playerID, cl.token, cl.spectator, state)
+	if cl.patches != nil {
+		note = cl.patches.next(state.Version, note, r.stateTree(note.Params.(*protocol.State)))
+	}
+	cl.send(note)
 }
 
 // Must be called with r.mu locked.
-func (r *Room) createStateFor(playerID game.PlayerID) *protocol.RoomState {
+func (r *Room) createStateFor(playerID game.PlayerID, cl *client) *protocol.RoomState {
 	if r.state == nil || r.state.version != r.room.Version {
 		r.state = r.createStateCache()
 	}
 
+	if cl.spectator {
+		if r.state.spectator != nil {
+			return r.state.spectator
+		}
+		return r.state.guesser
+	}
+
 	// Temporary verbose access to attempt to figure out which of these is (impossibly) failing.
 	room := r.room
 	players := room.Players
 	player := players[playerID]
 	spymaster := player.Spymaster
 
+	if r.state.sides != nil {
+		return r.state.sides[player.Team]
+	}
+
 	if spymaster {
 		return r.state.spymaster
 	}
@@ -497,28 +1052,105 @@ type stateCache struct {
 	version   int
 	guesser   *protocol.RoomState
 	spymaster *protocol.RoomState
+	sides     []*protocol.RoomState // Per-side views, only in duet games.
+	spectator *protocol.RoomState   // Only in stream-safe rooms; otherwise, spectators see the guesser view.
+
+	trees map[*protocol.RoomState]interface{} // The states as decoded JSON, for patches.
 }
 
+// noSide is passed to createRoomState when the state isn't for a duet side.
+const noSide = game.Team(-1)
+
 func (r *Room) createStateCache() *stateCache {
-	return &stateCache{
+	c := &stateCache{
 		version:   r.room.Version,
-		guesser:   r.createRoomState(false),
-		spymaster: r.createRoomState(true),
+		guesser:   r.createRoomState(false, noSide),
+		spymaster: r.createRoomState(true, noSide),
+	}
+
+	if r.room.Duet != nil {
+		c.sides = make([]*protocol.RoomState, len(r.room.Teams))
+		for side := range c.sides {
+			c.sides[side] = r.createRoomState(false, game.Team(side))
+		}
+	}
+
+	if r.streamSafe {
+		c.spectator = r.createStreamSafeState()
 	}
+
+	return c
 }
 
-func (r *Room) createRoomState(spymaster bool) *protocol.RoomState {
+func (r *Room) createRoomState(spymaster bool, side game.Team) *protocol.RoomState {
 	room := r.room
 
 	s := &protocol.RoomState{
-		Version:   room.Version,
-		Teams:     make([][]*protocol.StatePlayer, len(room.Teams)),
-		Turn:      room.Turn,
-		Winner:    room.Winner,
-		Board:     make([][]*protocol.StateTile, room.Board.Rows),
-		WordsLeft: room.Board.WordCounts,
-		Lists:     make([]*protocol.StateWordList, len(room.WordLists)),
-		HideBomb:  r.hideBomb,
+		Mode:         room.Mode,
+		Version:      room.Version,
+		Teams:        make([][]*protocol.StatePlayer, len(room.Teams)),
+		NumTeams:     len(room.Teams),
+		Turn:         room.Turn,
+		Winner:       room.Winner,
+		Eliminated:   append([]bool(nil), room.Eliminated...),
+		Board:        make([][]*protocol.StateTile, room.Board.Rows),
+		WordsLeft:    room.Board.WordCounts,
+		Lists:        make([]*protocol.StateWordList, len(room.WordLists)),
+		Log:          make([]*protocol.StateEvent, len(room.Log)),
+		UndoRule:     room.UndoRule,
+		RevealShare:  room.RevealShare,
+		HideBomb:     r.hideBomb,
+		Rows:         room.Rows,
+		Cols:         room.Cols,
+		Host:         r.host,
+		Locked:       r.locked,
+		HostOnly:     r.hostOnly,
+		StreamSafe:   r.streamSafe,
+		Spectators:   r.stateSpectators(),
+		HideTeamChat: r.hideTeamChat,
+		Invites:      len(r.invites),
+	}
+
+	if room.Duet != nil {
+		s.Duet = &protocol.StateDuet{
+			TimerTokens: room.Duet.TimerTokens,
+			Won:         room.Duet.Won,
+			Lost:        room.Duet.Lost,
+		}
+	}
+
+	if clue := room.Clue; clue != nil {
+		s.Clue = &protocol.StateClue{
+			Team:      clue.Team,
+			Word:      clue.Word,
+			Count:     clue.Count,
+			Unlimited: clue.Unlimited,
+		}
+
+		if clue.Limited() {
+			guessesLeft := clue.GuessesLeft
+			s.Clue.GuessesLeft = &guessesLeft
+		}
+	}
+
+	undone := undoneEvents(room.Log)
+	for i, e := range room.Log {
+		s.Log[i] = createStateEvent(e)
+		if undone[i] && s.Log[i].Tile != nil {
+			s.Log[i].Tile.View = nil
+		}
+	}
+
+	if room.Over() {
+		seed := strconv.FormatInt(room.Seed, 10)
+		s.Seed = &seed
+	}
+
+	if u := room.Undo; u != nil {
+		s.Undo = &protocol.StateUndo{
+			Event: createStateEvent(u.Event),
+			Votes: append([]game.PlayerID{}, u.Votes...),
+		}
 	}
 
 	if r.turnDeadline != nil {
@@ -535,6 +1167,7 @@ func (r *Room) createRoomState(spymaster bool) *protocol.RoomState {
 				PlayerID:  id,
 				Nickname:  p.Nickname,
 				Spymaster: p.Spymaster,
+				Away:      r.players[id] == nil,
 			})
 		}
 
@@ -544,34 +1177,37 @@ func (r *Room) createRoomState(spymaster bool) *protocol.RoomState {
 	}
 
 	for row := range s.Board {
-		tiles := make([]*protocol.StateTile, room.Board.Cols)
-		for col := range tiles {
-			tile := room.Board.Get(row, col)
-			sTile := &protocol.StateTile{
-				Word:     tile.Word,
-				Revealed: tile.Revealed,
-			}
+		s.Board[row] = make([]*protocol.StateTile, room.Board.Cols)
+	}
 
-			if spymaster || tile.Revealed || room.Winner != nil {
-				view := &protocol.StateView{
-					Team:    tile.Team,
-					Neutral: tile.Neutral,
-					Bomb:    tile.Bomb,
-				}
+	room.Board.Tiles(func(row, col int, tile *game.Tile) bool {
+		sTile := &protocol.StateTile{
+			Word:     tile.Word,
+			Revealed: tile.Revealed,
+			Votes:    room.TileVotes(row, col),
+		}
 
-				if view.Bomb && !tile.Revealed && room.Winner == nil && r.hideBomb {
-					view.Neutral = true
-					view.Bomb = false
-				}
+		if room.Duet != nil {
+			sTile.View = duetView(tile, side)
+			sTile.Marked = append([]bool(nil), tile.Marked...)
+		} else if spymaster || tile.Revealed || room.Winner != nil {
+			view := &protocol.StateView{
+				Team:    tile.Team,
+				Neutral: tile.Neutral,
+				Bomb:    tile.Bomb,
+			}
 
-				sTile.View = view
+			if view.Bomb && !tile.Revealed && room.Winner == nil && r.hideBomb {
+				view.Neutral = true
+				view.Bomb = false
 			}
 
-			tiles[col] = sTile
+			sTile.View = view
 		}
 
-		s.Board[row] = tiles
-	}
+		s.Board[row][col] = sTile
+		return true
+	})
 
 	for i, wl := range room.WordLists {
 		s.Lists[i] = &protocol.StateWordList{
@@ -585,6 +1221,137 @@ func (r *Room) createRoomState(spymaster bool) *protocol.RoomState {
 	return s
 }
 
+func createStateEvent(e *game.Event) *protocol.StateEvent {
+	se := &protocol.StateEvent{
+		Type:     e.Type,
+		Time:     e.Time,
+		Turn:     e.Turn,
+		PlayerID: e.Player,
+		Nickname: e.Nickname,
+		Voters:   e.Voters,
+	}
+
+	switch e.Type {
+	case game.EventReveal:
+		se.Tile = &protocol.StateEventTile{
+			Row:  e.Row,
+			Col:  e.Col,
+			Word: e.Tile.Word,
+		}
+
+		if e.Tile.Duet != nil {
+			// Only the key card in play is shown; the other side's may still be secret.
+			se.Tile.View = duetView(e.Tile, game.DuetKeySide(e.Turn))
+		} else {
+			se.Tile.View = &protocol.StateView{
+				Team:    e.Tile.Team,
+				Neutral: e.Tile.Neutral,
+				Bomb:    e.Tile.Bomb,
+			}
+		}
+
+	case game.EventClue:
+		se.Clue = &protocol.StateClue{
+			Team:      e.Clue.Team,
+			Word:      e.Clue.Word,
+			Count:     e.Clue.Count,
+			Unlimited: e.Clue.Unlimited,
+		}
+
+	case game.EventChangeTeam, game.EventJoin:
+		team := e.Team
+		se.Team = &team
+
+	case game.EventChangeRole:
+		spymaster := e.Spymaster
+		se.Spymaster = &spymaster
+
+	case game.EventUndo:
+		// An undone reveal hides the tile again, so it mustn't be shown here.
+		se.Undone = createStateEvent(e.Undone)
+		if se.Undone.Tile != nil {
+			se.Undone.Tile.View = nil
+		}
+
+	case game.EventRandomizeTeams:
+		se.Teams = e.Teams
+	}
+
+	return se
+}
+
+// undoneEvents returns the indexes of the logged moves which were undone. An
+// undo always takes back the most recent reveal or turn end, and a move can
+// only be undone once.
+func undoneEvents(log []*game.Event) map[int]bool {
+	undone := make(map[int]bool)
+	last := -1
+
+	for i, e := range log {
+		switch e.Type {
+		case game.EventReveal, game.EventEndTurn:
+			last = i
+		case game.EventUndo:
+			if last >= 0 {
+				undone[last] = true
+				last = -1
+			}
+		}
+	}
+
+	return undone
+}
+
+// duetView shows a tile as it appears on a side's key card. Revealed tiles
+// which are bystanders on that side's key card are shown as they appear on the
+// other key card instead.
+func duetView(tile *game.Tile, side game.Team) *protocol.StateView {
+	roleView := func(s game.Team) *protocol.StateView {
+		switch tile.Duet[s] {
+		case game.DuetAgent:
+			return &protocol.StateView{Team: s}
+		case game.DuetAssassin:
+			return &protocol.StateView{Bomb: true}
+		default:
+			return &protocol.StateView{Neutral: true}
+		}
+	}
+
+	if side != noSide && (!tile.Revealed || tile.Duet[side] != game.DuetBystander) {
+		return roleView(side)
+	}
+
+	if !tile.Revealed {
+		return nil
+	}
+
+	for s, role := range tile.Duet {
+		if role != game.DuetBystander {
+			return roleView(game.Team(s))
+		}
+	}
+
+	return nil
+}
+
+// Replay returns the replay of the current game if it has ended, otherwise
+// the replay of the previous game. If neither exists, it returns nil. Replays
+// reveal the key, so tok must be a reconnect token or the room's key, as room
+// IDs alone are widely known.
+func (r *Room) Replay(tok string) (*game.Replay, error) {
+	if _, ok := r.verifyToken(tok); !ok && !r.verifyKey(tok) {
+		return nil, ErrInvalidToken
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.room.Over() {
+		return r.room.Replay(), nil
+	}
+	return r.room.LastReplay, nil
+}
+
 // Must be called with r.mu locked.
 func (r *Room) changeTurnMode(timed bool) {
 	if r.timed == timed {
@@ -630,7 +1397,7 @@ func (r *Room) timerEndTurn() {
 	r.turnTimer = nil
 	r.turnDeadline = nil
 
-	if r.room.Winner != nil || r.turnSeconds == 0 {
+	if r.room.Over() || r.turnSeconds == 0 {
 		return
 	}
 
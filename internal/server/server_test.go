@@ -0,0 +1,246 @@
+package server
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/zikaeroh/codies/internal/game"
+	"github.com/zikaeroh/codies/internal/protocol"
+	"gotest.tools/v3/assert"
+)
+
+func TestResults(t *testing.T) {
+	s, stop := runServer(t, nil)
+	defer stop()
+
+	room, err := s.CreateRoom(context.Background(), "", "room", "", false)
+	assert.NilError(t, err)
+
+	ctx := context.Background()
+
+	var results []*protocol.Result
+	var states int
+	cl := newFakeClient(room, "1")
+	cl.send = func(n protocol.ServerNote) {
+		switch p := n.Params.(type) {
+		case *protocol.Result:
+			results = append(results, p)
+		case *protocol.State:
+			states++
+		}
+	}
+
+	room.mu.Lock()
+	room.connect("1", "player", cl.client)
+	room.connect("2", "other", newFakeClient(room, "2").client)
+	turn := room.room.Turn
+	room.room.ChangeTeam("1", 1-turn)
+	room.room.ChangeTeam("2", turn)
+	room.mu.Unlock()
+
+	var id int64
+	send := func(n *protocol.ClientNote) *protocol.Result {
+		t.Helper()
+		id++
+		n.ID = &id
+		assert.NilError(t, room.handleNote(ctx, "1", n))
+		assert.Assert(t, len(results) != 0)
+		r := results[len(results)-1]
+		assert.Equal(t, r.ID, id)
+		return r
+	}
+
+	r := send(note(t, room, protocol.ChangeRoleMethod, &protocol.ChangeRoleParams{Spymaster: true}))
+	assert.Assert(t, r.OK)
+	assert.Equal(t, r.Code, protocol.ResultCode(""))
+
+	r = send(note(t, room, protocol.GiveClueMethod, &protocol.GiveClueParams{Word: "ocean", Count: 1}))
+	assert.Equal(t, r.Code, protocol.ResultNotYourTurn)
+
+	r = send(note(t, room, protocol.RevealMethod, &protocol.RevealParams{Row: 0, Col: 0}))
+	assert.Equal(t, r.Code, protocol.ResultNotYourTurn)
+
+	r = send(note(t, room, protocol.ChangeTeamMethod, &protocol.ChangeTeamParams{Team: 5}))
+	assert.Equal(t, r.Code, protocol.ResultInvalidParams)
+
+	r = send(note(t, room, protocol.AddPacksMethod, &protocol.AddPacksParams{
+		Packs: []struct {
+			Name  string   `json:"name"`
+			Words []string `json:"words"`
+		}{{Name: "short", Words: []string{"one"}}},
+	}))
+	assert.Equal(t, r.Code, protocol.ResultInvalidParams)
+
+	// Packs must fill the next game's board, not just the smallest.
+	room.mu.Lock()
+	room.room.ChangeBoardSize(6, 6)
+	room.mu.Unlock()
+
+	r = send(note(t, room, protocol.AddPacksMethod, &protocol.AddPacksParams{
+		Packs: []struct {
+			Name  string   `json:"name"`
+			Words []string `json:"words"`
+		}{{Name: "small", Words: make([]string, 30)}},
+	}))
+	assert.Equal(t, r.Code, protocol.ResultInvalidParams)
+
+	badSeed := "1e9"
+	r = send(note(t, room, protocol.NewGameMethod, &protocol.NewGameParams{Seed: &badSeed}))
+	assert.Equal(t, r.Code, protocol.ResultInvalidParams)
+
+	r = send(&protocol.ClientNote{Method: protocol.RevealMethod, Version: room.room.Version, Params: []byte(`{"row": "x"}`)})
+	assert.Equal(t, r.Code, protocol.ResultInvalidParams)
+
+	// Stale notes get the current state, then the result.
+	before := states
+	n := note(t, room, protocol.EndTurnMethod, &protocol.EndTurnParams{})
+	n.Version--
+	r = send(n)
+	assert.Equal(t, r.Code, protocol.ResultStaleVersion)
+	assert.Equal(t, states, before+1)
+
+	room.mu.Lock()
+	room.changeHostOnly(true)
+	room.changeHost("2")
+	room.mu.Unlock()
+
+	r = send(note(t, room, protocol.ChangeHideBombMethod, &protocol.ChangeHideBombParams{HideBomb: true}))
+	assert.Equal(t, r.Code, protocol.ResultForbidden)
+
+	// Dropped chat messages aren't reported as sent.
+	r = send(note(t, room, protocol.ChatMethod, &protocol.ChatParams{Text: strings.Repeat("x", maxChatLength+1)}))
+	assert.Equal(t, r.Code, protocol.ResultInvalidParams)
+
+	for i := 0; i < chatBurst; i++ {
+		r = send(note(t, room, protocol.ChatMethod, &protocol.ChatParams{Text: "spam"}))
+		assert.Assert(t, r.OK)
+	}
+	r = send(note(t, room, protocol.ChatMethod, &protocol.ChatParams{Text: "spam"}))
+	assert.Equal(t, r.Code, protocol.ResultRateLimited)
+
+	// Notes without an ID get no result.
+	results = nil
+	assert.NilError(t, room.handleNote(ctx, "1", note(t, room, protocol.RevealMethod, &protocol.RevealParams{})))
+	assert.Equal(t, len(results), 0)
+	assert.Equal(t, room.room.Players["1"].Team, game.Team(1-turn))
+}
+
+// BenchmarkEncodeState compares the codecs on a full state, reporting the size
+// of each encoded note.
+func BenchmarkEncodeState(b *testing.B) {
+	s, stop := runServer(b, nil)
+	defer stop()
+
+	room, err := s.CreateRoom(context.Background(), "", "room", "", false)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for i := 0; i < 8; i++ {
+		id := strconv.Itoa(i)
+		room.room.AddPlayer(id, "player"+id)
+	}
+	_ = room.room.NewGame("")
+
+	for i := 0; i < 10; i++ {
+		guesser := room.room.Teams[room.room.Turn][0]
+		room.room.Reveal(guesser, i/5, i%5)
+	}
+
+	note := protocol.NewStateNote("0", "token", false, room.createRoomState(false, noSide))
+
+	for _, subprotocol := range append([]string{""}, protocol.Subprotocols...) {
+		codec := protocol.CodecFor(subprotocol)
+		name := subprotocol
+		if name == "" {
+			name = "json"
+		}
+
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			var size int
+			for i := 0; i < b.N; i++ {
+				enc, err := codec.MarshalServerNote(&note)
+				if err != nil {
+					b.Fatal(err)
+				}
+				size = len(enc)
+			}
+
+			b.ReportMetric(float64(size), "bytes")
+		})
+	}
+}
+
+func TestReplayAccess(t *testing.T) {
+	s, stop := runServer(t, nil)
+	defer stop()
+
+	room, err := s.CreateRoom(context.Background(), "", "room", "pass", false)
+	assert.NilError(t, err)
+
+	room.mu.Lock()
+	room.connect("1", "host", newFakeClient(room, "1").client)
+	winner := game.Team(0)
+	room.room.Winner = &winner
+	room.mu.Unlock()
+
+	// The replay reveals the key, so knowing the room ID isn't enough.
+	_, err = room.Replay("")
+	assert.Equal(t, err, ErrInvalidToken)
+
+	invite, _, err := room.CreateInvite(room.newToken("1"), &protocol.InviteRequest{})
+	assert.NilError(t, err)
+	_, err = room.Replay(invite)
+	assert.Equal(t, err, ErrInvalidToken)
+
+	for _, tok := range []string{room.Key(), room.newToken("1")} {
+		replay, err := room.Replay(tok)
+		assert.NilError(t, err)
+		assert.Assert(t, replay != nil)
+	}
+}
+
+func TestUndoHidesReveal(t *testing.T) {
+	s, stop := runServer(t, nil)
+	defer stop()
+
+	room, err := s.CreateRoom(context.Background(), "", "room", "", false)
+	assert.NilError(t, err)
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	room.connect("1", "guesser", newFakeClient(room, "1").client)
+	room.connect("2", "spymaster", newFakeClient(room, "2").client)
+
+	g := room.room
+	team := g.Turn
+	g.ChangeTeam("1", team)
+	g.ChangeTeam("2", 1-team)
+	g.ChangeRole("2", true)
+
+	var row, col int
+	g.Board.Tiles(func(r, c int, tile *game.Tile) bool {
+		row, col = r, c
+		return !tile.Neutral
+	})
+
+	assert.NilError(t, g.Reveal("1", row, col))
+	assert.NilError(t, g.VoteUndo("2"))
+	assert.Assert(t, !g.Board.Get(row, col).Revealed)
+
+	// The tile is secret again, so neither the reveal nor its undo may show it.
+	state := room.createRoomState(false, noSide)
+	reveal := state.Log[len(state.Log)-2]
+	assert.Equal(t, reveal.Type, game.EventReveal)
+	assert.Assert(t, reveal.Tile.View == nil)
+
+	undo := state.Log[len(state.Log)-1]
+	assert.Equal(t, undo.Type, game.EventUndo)
+	assert.Equal(t, undo.Undone.Tile.Row, row)
+	assert.Assert(t, undo.Undone.Tile.View == nil)
+}
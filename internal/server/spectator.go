@@ -0,0 +1,99 @@
+package server
+
+import (
+	"sort"
+
+	"github.com/zikaeroh/codies/internal/game"
+	"github.com/zikaeroh/codies/internal/protocol"
+)
+
+// spectatorMethods may be used by spectators; spectators can't otherwise take
+// part in the game.
+var spectatorMethods = map[protocol.ClientMethod]bool{
+	protocol.ChatMethod:           true,
+	protocol.ChangeNicknameMethod: true,
+	protocol.ChangeRoleMethod:     true,
+}
+
+// changeSpectator moves a connected player out of the game to watch it, or a
+// spectator into the game.
+//
+// Must be called with r.mu locked.
+func (r *Room) changeSpectator(playerID game.PlayerID, cl *client, spectator bool) {
+	if spectator {
+		if p := r.room.Players[playerID]; p != nil {
+			cl.nickname = p.Nickname
+		}
+		cl.spectator = true
+		r.room.RemovePlayer(playerID)
+		r.ensureHost()
+	} else {
+		if r.locked {
+			return
+		}
+		cl.spectator = false
+		r.room.AddPlayer(playerID, cl.nickname)
+		r.ensureHost()
+	}
+
+	r.room.Version++
+}
+
+// Must be called with r.mu locked.
+func (r *Room) changeSpectatorNickname(cl *client, nickname string) {
+	if cl.nickname == nickname {
+		return
+	}
+
+	cl.nickname = nickname
+	r.room.Version++
+}
+
+// Must be called with r.mu locked.
+func (r *Room) changeStreamSafe(streamSafe bool) {
+	if r.streamSafe == streamSafe {
+		return
+	}
+
+	r.streamSafe = streamSafe
+	r.room.Version++
+}
+
+// createStreamSafeState creates the state shown to spectators of a
+// stream-safe room. It is the guesser view, but the key stays hidden even
+// once the game is over, so that a stream never shows anything the players
+// haven't revealed themselves.
+func (r *Room) createStreamSafeState() *protocol.RoomState {
+	s := r.createRoomState(false, noSide)
+	s.Seed = nil
+
+	for _, row := range s.Board {
+		for _, tile := range row {
+			if !tile.Revealed {
+				tile.View = nil
+			}
+		}
+	}
+
+	return s
+}
+
+// Must be called with r.mu locked.
+func (r *Room) stateSpectators() []*protocol.StatePlayer {
+	spectators := []*protocol.StatePlayer{}
+
+	for id, cl := range r.players {
+		if cl.spectator {
+			spectators = append(spectators, &protocol.StatePlayer{
+				PlayerID: id,
+				Nickname: cl.nickname,
+			})
+		}
+	}
+
+	sort.Slice(spectators, func(i, j int) bool {
+		return spectators[i].PlayerID < spectators[j].PlayerID
+	})
+
+	return spectators
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zikaeroh/codies/internal/game"
+	"github.com/zikaeroh/codies/internal/protocol"
+	"gotest.tools/v3/assert"
+)
+
+func TestSpectator(t *testing.T) {
+	s, stop := runServer(t, nil)
+	defer stop()
+
+	room, err := s.CreateRoom(context.Background(), "", "room", "", false)
+	assert.NilError(t, err)
+
+	ctx := context.Background()
+
+	var last *protocol.State
+	watcher := newFakeClient(room, "2")
+	watcher.spectator = true
+	watcher.nickname = "watcher"
+	watcher.send = func(n protocol.ServerNote) { last = n.Params.(*protocol.State) }
+
+	room.mu.Lock()
+	room.connect("1", "player", newFakeClient(room, "1").client)
+	room.connect("2", "watcher", watcher.client)
+	assert.Assert(t, room.room.Players["2"] == nil)
+	assert.Assert(t, last.Spectator)
+	assert.Equal(t, len(last.RoomState.Spectators), 1)
+	assert.Equal(t, last.RoomState.Spectators[0].Nickname, "watcher")
+	room.mu.Unlock()
+
+	// Spectators can't take game actions.
+	version := room.room.Version
+	assert.NilError(t, room.handleNote(ctx, "2", note(t, room, protocol.NewGameMethod, &protocol.NewGameParams{})))
+	assert.Equal(t, room.room.Version, version)
+
+	// Joining the game.
+	assert.NilError(t, room.handleNote(ctx, "2", note(t, room, protocol.ChangeRoleMethod, &protocol.ChangeRoleParams{})))
+	assert.Assert(t, !watcher.spectator)
+	assert.Equal(t, room.room.Players["2"].Nickname, "watcher")
+	assert.Assert(t, !last.Spectator)
+	assert.Assert(t, !room.room.Players["2"].Spymaster)
+
+	// Spectators can't rejoin a locked room.
+	room.mu.Lock()
+	room.changeSpectator("2", watcher.client, true)
+	room.changeLocked(true)
+	room.mu.Unlock()
+	assert.NilError(t, room.handleNote(ctx, "2", note(t, room, protocol.ChangeRoleMethod, &protocol.ChangeRoleParams{Spymaster: true})))
+	assert.Assert(t, watcher.spectator)
+	assert.Assert(t, room.room.Players["2"] == nil)
+
+	room.mu.Lock()
+	room.changeLocked(false)
+	room.changeSpectator("2", watcher.client, false)
+	room.mu.Unlock()
+
+	// And leaving it again.
+	assert.NilError(t, room.handleNote(ctx, "2", note(t, room, protocol.ChangeRoleMethod, &protocol.ChangeRoleParams{Spectator: true})))
+	assert.Assert(t, watcher.spectator)
+	assert.Assert(t, room.room.Players["2"] == nil)
+}
+
+func TestStreamSafe(t *testing.T) {
+	s, stop := runServer(t, nil)
+	defer stop()
+
+	room, err := s.CreateRoom(context.Background(), "", "room", "", false)
+	assert.NilError(t, err)
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	var last *protocol.State
+	watcher := newFakeClient(room, "2")
+	watcher.spectator = true
+	watcher.send = func(n protocol.ServerNote) { last = n.Params.(*protocol.State) }
+
+	room.connect("1", "player", newFakeClient(room, "1").client)
+	room.connect("2", "watcher", watcher.client)
+
+	winner := game.Team(0)
+	room.room.Winner = &winner
+	room.room.Version++
+
+	room.sendAll()
+	assert.Assert(t, last.RoomState.Board[0][0].View != nil)
+	assert.Assert(t, last.RoomState.Seed != nil)
+
+	room.changeStreamSafe(true)
+	room.sendAll()
+	assert.Assert(t, last.RoomState.Board[0][0].View == nil)
+	assert.Assert(t, last.RoomState.Seed == nil)
+}
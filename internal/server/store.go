@@ -0,0 +1,183 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/zikaeroh/codies/internal/game"
+	"github.com/zikaeroh/ctxlog"
+	"go.uber.org/zap"
+)
+
+// RoomStore persists rooms, so that they survive server restarts.
+type RoomStore interface {
+	// LoadRooms returns all saved rooms. Rooms which can't be read are logged
+	// and skipped, so that one bad room doesn't prevent the rest from loading.
+	LoadRooms(ctx context.Context) ([]*RoomSnapshot, error)
+
+	// SaveRoom saves a room, replacing any previous snapshot of it.
+	SaveRoom(snap *RoomSnapshot) error
+
+	// DeleteRoom deletes a saved room. Deleting a room which was never saved
+	// is not an error.
+	DeleteRoom(id string) error
+
+	// LoadServerState returns the saved server state, or nil if none has
+	// been saved.
+	LoadServerState() (*ServerState, error)
+
+	// SaveServerState saves the server state.
+	SaveServerState(state *ServerState) error
+}
+
+// RoomSnapshot is a saved room.
+type RoomSnapshot struct {
+	Name         string          `json:"name"`
+	PasswordHash []byte          `json:"passwordHash"`
+	Public       bool            `json:"public"`
+	ID           string          `json:"id"`
+	LastPlayerID int64           `json:"lastPlayerID"` // Player ID generator state.
+	Timed        bool            `json:"timed"`
+	TurnSeconds  int             `json:"turnSeconds"`
+	HideBomb     bool            `json:"hideBomb"`
+	Game         json.RawMessage `json:"game"` // The serialized game.Room.
+
+	Host            game.PlayerID   `json:"host"`
+	Locked          bool            `json:"locked"`
+	HostOnly        bool            `json:"hostOnly"`
+	StreamSafe      bool            `json:"streamSafe"`
+	HideTeamChat    bool            `json:"hideTeamChat"`
+	ChatID          int64           `json:"chatID"` // Chat isn't saved, but its IDs must keep increasing.
+	BannedIDs       []game.PlayerID `json:"bannedIDs"`
+	BannedNicknames []string        `json:"bannedNicknames"`
+	Invites         []*Invite       `json:"invites"`
+	InviteID        int64           `json:"inviteID"` // Invite IDs must keep increasing, so revoked invites stay revoked.
+}
+
+// ServerState is the state shared by all rooms which must be kept for saved
+// rooms to remain reachable.
+type ServerState struct {
+	// Room ID generator state. Room IDs are only valid for the salt they were
+	// generated with.
+	Salt string `json:"salt"`
+	Last int64  `json:"last"`
+
+	// Key used to sign reconnect tokens.
+	TokenKey []byte `json:"tokenKey"`
+}
+
+// FileStore is a RoomStore which stores each room as a JSON file in a
+// directory.
+type FileStore struct {
+	dir string
+}
+
+var _ RoomStore = (*FileStore)(nil)
+
+const (
+	fileStoreRooms = "rooms"
+	fileStoreState = "server.json"
+)
+
+// NewFileStore creates a FileStore in the given directory, creating it if
+// needed.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(filepath.Join(dir, fileStoreRooms), 0o700); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (f *FileStore) roomPath(id string) string {
+	return filepath.Join(f.dir, fileStoreRooms, id+".json")
+}
+
+func (f *FileStore) LoadRooms(ctx context.Context) ([]*RoomSnapshot, error) {
+	files, err := ioutil.ReadDir(filepath.Join(f.dir, fileStoreRooms))
+	if err != nil {
+		return nil, err
+	}
+
+	snaps := make([]*RoomSnapshot, 0, len(files))
+
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+
+		snap := &RoomSnapshot{}
+		if err := readJSON(filepath.Join(f.dir, fileStoreRooms, file.Name()), snap); err != nil {
+			ctxlog.Error(ctx, "error reading saved room", zap.String("file", file.Name()), zap.Error(err))
+			continue
+		}
+		snaps = append(snaps, snap)
+	}
+
+	return snaps, nil
+}
+
+func (f *FileStore) SaveRoom(snap *RoomSnapshot) error {
+	return writeJSON(f.roomPath(snap.ID), snap)
+}
+
+func (f *FileStore) DeleteRoom(id string) error {
+	err := os.Remove(f.roomPath(id))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (f *FileStore) LoadServerState() (*ServerState, error) {
+	state := &ServerState{}
+	if err := readJSON(filepath.Join(f.dir, fileStoreState), state); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return state, nil
+}
+
+func (f *FileStore) SaveServerState(state *ServerState) error {
+	return writeJSON(filepath.Join(f.dir, fileStoreState), state)
+}
+
+func readJSON(path string, v interface{}) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// writeJSON writes a file atomically, so that a crash never leaves a partially
+// written file behind.
+func writeJSON(path string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zikaeroh/codies/internal/game"
+	"github.com/zikaeroh/codies/internal/protocol"
+	"gotest.tools/v3/assert"
+)
+
+func tempStore(t *testing.T) *FileStore {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "codies")
+	assert.NilError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	store, err := NewFileStore(dir)
+	assert.NilError(t, err)
+	return store
+}
+
+func TestFileStore(t *testing.T) {
+	store := tempStore(t)
+
+	state, err := store.LoadServerState()
+	assert.NilError(t, err)
+	assert.Assert(t, state == nil)
+
+	want := &ServerState{Salt: "salt", Last: 3, TokenKey: []byte("key")}
+	assert.NilError(t, store.SaveServerState(want))
+	state, err = store.LoadServerState()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, state, want)
+
+	snap := &RoomSnapshot{Name: "name", ID: "id", TurnSeconds: 60, Game: []byte(`{"version":1}`)}
+	assert.NilError(t, store.SaveRoom(snap))
+	assert.NilError(t, store.SaveRoom(snap))
+
+	// Unreadable rooms are skipped.
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(store.dir, fileStoreRooms, "bad.json"), []byte("{"), 0o600))
+
+	snaps, err := store.LoadRooms(context.Background())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, snaps, []*RoomSnapshot{snap})
+
+	assert.NilError(t, store.DeleteRoom("id"))
+	assert.NilError(t, store.DeleteRoom("id"))
+
+	assert.NilError(t, store.DeleteRoom("bad"))
+
+	snaps, err = store.LoadRooms(context.Background())
+	assert.NilError(t, err)
+	assert.Equal(t, len(snaps), 0)
+}
+
+func runServer(t testing.TB, store RoomStore) (*Server, func()) {
+	t.Helper()
+	return runServerConfig(t, DefaultConfig(), store)
+}
+
+func runServerConfig(t testing.TB, config Config, store RoomStore) (*Server, func()) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := NewServer(config, store)
+	done := make(chan error)
+	go func() { done <- s.Run(ctx) }()
+
+	return s, func() {
+		cancel()
+		assert.Equal(t, <-done, context.Canceled)
+	}
+}
+
+func TestServerRestore(t *testing.T) {
+	store := tempStore(t)
+
+	s, stop := runServer(t, store)
+	room, err := s.CreateRoom(context.Background(), "", "room", "pass", false)
+	assert.NilError(t, err)
+
+	room.mu.Lock()
+	room.changeTurnMode(true)
+	room.room.AddPack("custom", make([]string, 30))
+	words := room.room.Board.Get(0, 0).Word
+	room.connect("1", "player", newFakeClient(room, "1").client)
+	room.mu.Unlock()
+
+	tok := room.newToken("1")
+	invite, _, err := room.CreateInvite(tok, &protocol.InviteRequest{})
+	assert.NilError(t, err)
+
+	stop()
+
+	s, stop = runServer(t, store)
+	defer stop()
+
+	restored := s.FindRoomByID(room.ID)
+	assert.Assert(t, restored != nil)
+	assert.Equal(t, restored, s.FindRoom("room"))
+	assert.Assert(t, restored.CheckPassword("pass"))
+
+	// As do invites.
+	assert.Equal(t, s.FindInvite(invite), restored)
+
+	restored.mu.Lock()
+	defer restored.mu.Unlock()
+	assert.Assert(t, restored.timed)
+	assert.Assert(t, restored.turnDeadline != nil)
+	assert.Equal(t, restored.room.Board.Get(0, 0).Word, words)
+	assert.Equal(t, len(restored.room.WordLists), 4)
+
+	// Reconnect tokens remain valid.
+	playerID, ok := restored.verifyToken(tok)
+	assert.Assert(t, ok)
+	assert.Equal(t, playerID, game.PlayerID("1"))
+
+	// New rooms don't reuse restored IDs.
+	other, err := s.CreateRoom(context.Background(), "", "other", "", false)
+	assert.NilError(t, err)
+	assert.Assert(t, other.ID != room.ID)
+}
+
+func TestRestoreNoTokenKey(t *testing.T) {
+	store := tempStore(t)
+	assert.NilError(t, store.SaveServerState(&ServerState{Salt: "salt", Last: 3}))
+
+	s := NewServer(DefaultConfig(), store)
+	assert.Equal(t, s.Run(context.Background()), errNoTokenKey)
+}
@@ -0,0 +1,25 @@
+package token
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestSigner(t *testing.T) {
+	s := NewSigner(NewKey())
+
+	tok := s.Sign([]byte("payload"))
+	payload, err := s.Verify(tok)
+	assert.NilError(t, err)
+	assert.Equal(t, string(payload), "payload")
+
+	other := NewSigner(NewKey())
+	_, err = other.Verify(tok)
+	assert.Equal(t, err, ErrInvalid)
+
+	for _, bad := range []string{"", "payload", ".", "cGF5bG9hZA.", "cGF5bG9hZB" + tok[len("cGF5bG9hZA"):], tok + "x"} {
+		_, err := s.Verify(bad)
+		assert.Equal(t, err, ErrInvalid, bad)
+	}
+}
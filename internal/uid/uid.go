@@ -29,6 +29,21 @@ func NewGenerator(salt string) *Generator {
 	}
 }
 
+// NewGeneratorFrom creates a new Generator with the specified salt which
+// continues after the raw ID last, as returned by Last. This allows a
+// generator to be restored without repeating IDs.
+func NewGeneratorFrom(salt string, last int64) *Generator {
+	g := NewGenerator(salt)
+	g.next.Store(last)
+	return g
+}
+
+// Last returns the raw form of the most recently generated ID, or zero if no
+// IDs have been generated.
+func (g *Generator) Last() int64 {
+	return g.next.Load()
+}
+
 // Next gets the next ID, in both an encoded string form and the raw integer form.
 func (g *Generator) Next() (string, int64) {
 	v := g.next.Inc()
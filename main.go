@@ -6,13 +6,16 @@ import (
 	"fmt"
 	"log"
 	"math/rand"
+	"net"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
 	"github.com/jessevdk/go-flags"
+	"github.com/mailru/easyjson"
 	"github.com/posener/ctxutil"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/tomwright/queryparam/v4"
@@ -21,6 +24,7 @@ import (
 	"github.com/zikaeroh/codies/internal/responder"
 	"github.com/zikaeroh/codies/internal/server"
 	"github.com/zikaeroh/codies/internal/version"
+	"github.com/zikaeroh/ctxjoin"
 	"github.com/zikaeroh/ctxlog"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
@@ -32,25 +36,54 @@ var args = struct {
 	Origins []string `long:"origins" env:"CODIES_ORIGINS" env-delim:"," description:"Additional valid origins for WebSocket connections"`
 	Prod    bool     `long:"prod" env:"CODIES_PROD" description:"Enables production mode"`
 	Debug   bool     `long:"debug" env:"CODIES_DEBUG" description:"Enables debug mode"`
+	DataDir string   `long:"data-dir" env:"CODIES_DATA_DIR" description:"Directory to save rooms in, to keep them across restarts"`
+	Config  string   `long:"config" env:"CODIES_CONFIG" description:"YAML file to read server limits from; flags and environment variables override it"`
+	Proxied bool     `long:"proxied" env:"CODIES_PROXIED" description:"Take client addresses from X-Forwarded-For or X-Real-IP, when behind a reverse proxy"`
+
+	Server server.Config `group:"Server Limits"`
 }{
-	Addr: ":5000",
+	Addr:   ":5000",
+	Server: server.DefaultConfig(),
 }
 
 var wsOpts *websocket.AcceptOptions
 
 func main() {
-	if argv := os.Args[1:]; len(argv) > 0 && argv[0] == "version" {
-		fmt.Println(version.Version())
-		return
+	if argv := os.Args[1:]; len(argv) > 0 {
+		switch argv[0] {
+		case "version":
+			fmt.Println(version.Version())
+			return
+		case "replay":
+			if err := checkReplays(argv[1:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
 	}
 
 	rand.Seed(time.Now().Unix())
 
+	// The config file is read first, so that flags and environment variables
+	// override it.
+	var configArgs struct {
+		Config string `long:"config" env:"CODIES_CONFIG"`
+	}
+	if _, err := flags.NewParser(&configArgs, flags.IgnoreUnknown).Parse(); err == nil && configArgs.Config != "" {
+		if err := server.LoadConfig(configArgs.Config, &args.Server); err != nil {
+			log.Fatalf("error reading config: %v", err)
+		}
+	}
+
 	if _, err := flags.Parse(&args); err != nil {
 		// Default flag parser prints messages, so just exit.
 		os.Exit(1)
 	}
 
+	if err := args.Server.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
 	if !args.Prod && !args.Debug {
 		log.Fatal("missing required option --prod or --debug")
 	} else if args.Prod && args.Debug {
@@ -66,6 +99,7 @@ func main() {
 	ctxlog.Info(ctx, "starting", zap.String("version", version.Version()))
 
 	wsOpts = &websocket.AcceptOptions{
+		Subprotocols:    protocol.Subprotocols,
 		OriginPatterns:  args.Origins,
 		CompressionMode: websocket.CompressionContextTakeover,
 	}
@@ -79,7 +113,17 @@ func main() {
 
 	g, ctx := errgroup.WithContext(ctx)
 
-	srv := server.NewServer()
+	var store server.RoomStore
+	if args.DataDir != "" {
+		fileStore, err := server.NewFileStore(args.DataDir)
+		if err != nil {
+			ctxlog.Fatal(ctx, "error opening data directory", zap.Error(err))
+		}
+		store = fileStore
+		ctxlog.Info(ctx, "saving rooms", zap.String("dataDir", args.DataDir))
+	}
+
+	srv := server.NewServer(args.Server, store)
 
 	r := chi.NewMux()
 
@@ -87,6 +131,10 @@ func main() {
 		return promhttp.InstrumentHandlerCounter(metricRequest, next)
 	})
 
+	if args.Proxied {
+		r.Use(middleware.RealIP)
+	}
+
 	r.Use(middleware.Heartbeat("/ping"))
 	r.Use(middleware.Recoverer)
 	r.NotFound(staticHandler().ServeHTTP)
@@ -109,6 +157,33 @@ func main() {
 			)
 		})
 
+		r.Get("/api/room/{id}/replay", func(w http.ResponseWriter, r *http.Request) {
+			room := srv.FindRoomByID(chi.URLParam(r, "id"))
+			if room == nil {
+				responder.Respond(w, responder.Status(http.StatusNotFound))
+				return
+			}
+
+			query := &protocol.ReplayQuery{}
+			if err := queryparam.Parse(r.URL.Query(), query); err != nil {
+				responder.Respond(w, responder.Status(http.StatusBadRequest))
+				return
+			}
+
+			replay, err := room.Replay(query.Token)
+			if err != nil {
+				responder.Respond(w, responder.Status(http.StatusUnauthorized))
+				return
+			}
+
+			if replay == nil {
+				responder.Respond(w, responder.Status(http.StatusNotFound))
+				return
+			}
+
+			responder.Respond(w, responder.Body(replay))
+		})
+
 		r.Group(func(r chi.Router) {
 			if !args.Debug {
 				r.Use(checkVersion)
@@ -129,6 +204,24 @@ func main() {
 				}
 			})
 
+			r.Get("/api/rooms", func(w http.ResponseWriter, r *http.Request) {
+				query := &protocol.RoomsQuery{}
+				if err := queryparam.Parse(r.URL.Query(), query); err != nil || query.Offset < 0 || query.Limit < 0 {
+					responder.Respond(w, responder.Status(http.StatusBadRequest))
+					return
+				}
+
+				if query.Limit == 0 || query.Limit > protocol.MaxRoomsLimit {
+					query.Limit = protocol.MaxRoomsLimit
+				}
+
+				rooms, total := srv.PublicRooms(query.Offset, query.Limit)
+				responder.Respond(w, responder.Body(&protocol.RoomsResponse{
+					Rooms: rooms,
+					Total: total,
+				}))
+			})
+
 			r.Post("/api/room", func(w http.ResponseWriter, r *http.Request) {
 				defer r.Body.Close()
 
@@ -138,7 +231,7 @@ func main() {
 					return
 				}
 
-				if msg, valid := req.Valid(); !valid {
+				if msg, valid := req.Valid(args.Server.MaxRoomName); !valid {
 					responder.Respond(w,
 						responder.Status(http.StatusBadRequest),
 						responder.Body(&protocol.RoomResponse{
@@ -149,9 +242,10 @@ func main() {
 				}
 
 				var room *server.Room
+				var key *string // Invite joins connect with the invite instead.
 				if req.Create {
 					var err error
-					room, err = srv.CreateRoom(ctx, req.RoomName, req.RoomPass)
+					room, err = srv.CreateRoom(ctx, clientAddr(r), req.RoomName, req.RoomPass, req.Public)
 					if err != nil {
 						switch err {
 						case server.ErrRoomExists:
@@ -168,6 +262,13 @@ func main() {
 									Error: stringPtr("Too many rooms."),
 								}),
 							)
+						case server.ErrTooManyFailedJoins:
+							responder.Respond(w,
+								responder.Status(http.StatusTooManyRequests),
+								responder.Body(&protocol.RoomResponse{
+									Error: stringPtr("Too many attempts; try again later."),
+								}),
+							)
 						default:
 							responder.Respond(w,
 								responder.Status(http.StatusInternalServerError),
@@ -178,9 +279,36 @@ func main() {
 						}
 						return
 					}
+					key = stringPtr(room.Key())
 				} else {
-					room = srv.FindRoom(req.RoomName)
-					if room == nil || room.Password != req.RoomPass {
+					var err error
+					if req.Invite != "" {
+						room, err = srv.JoinInvite(clientAddr(r), req.Invite)
+					} else {
+						room, err = srv.JoinRoom(clientAddr(r), req.RoomName, req.RoomPass)
+						if room != nil {
+							key = stringPtr(room.Key())
+						}
+					}
+					switch err {
+					case nil:
+					case server.ErrTooManyFailedJoins:
+						responder.Respond(w,
+							responder.Status(http.StatusTooManyRequests),
+							responder.Body(&protocol.RoomResponse{
+								Error: stringPtr("Too many failed attempts; try again later."),
+							}),
+						)
+						return
+					case server.ErrInvalidInvite:
+						responder.Respond(w,
+							responder.Status(http.StatusNotFound),
+							responder.Body(&protocol.RoomResponse{
+								Error: stringPtr("Invite is invalid, expired, or revoked."),
+							}),
+						)
+						return
+					default:
 						responder.Respond(w,
 							responder.Status(http.StatusNotFound),
 							responder.Body(&protocol.RoomResponse{
@@ -192,37 +320,109 @@ func main() {
 				}
 
 				responder.Respond(w, responder.Body(&protocol.RoomResponse{
-					ID: &room.ID,
+					ID:  &room.ID,
+					Key: key,
 				}))
 			})
 
 			r.Get("/api/ws", func(w http.ResponseWriter, r *http.Request) {
-				query := &protocol.WSQuery{}
-				if err := queryparam.Parse(r.URL.Query(), query); err != nil {
-					responder.Respond(w, responder.Status(http.StatusBadRequest))
+				query, room := parseConnQuery(w, r, srv)
+				if room == nil {
 					return
 				}
 
-				if _, valid := query.Valid(); !valid {
-					responder.Respond(w, responder.Status(http.StatusBadRequest))
+				c, err := websocket.Accept(w, r, wsOpts)
+				if err != nil {
 					return
 				}
 
-				room := srv.FindRoomByID(query.RoomID)
+				g.Go(func() error {
+					room.HandleConn(ctx, query, c)
+					return nil
+				})
+			})
+
+			// A fallback for clients which can't use WebSockets.
+			r.Get("/api/events", func(w http.ResponseWriter, r *http.Request) {
+				query, room := parseConnQuery(w, r, srv)
 				if room == nil {
+					return
+				}
+
+				ctx, cancel := ctxjoin.AddCancel(r.Context(), ctx)
+				defer cancel()
+
+				room.HandleEvents(ctx, query, w)
+			})
+
+			r.Post("/api/room/{id}/action", func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+
+				room := srv.FindRoomByID(chi.URLParam(r, "id"))
+				if room == nil {
+					responder.Respond(w, responder.Status(http.StatusNotFound))
+					return
+				}
+
+				note := &protocol.ClientNote{}
+				body := http.MaxBytesReader(w, r.Body, protocol.MaxNoteSize)
+				if err := json.NewDecoder(body).Decode(note); err != nil {
 					responder.Respond(w, responder.Status(http.StatusBadRequest))
 					return
 				}
 
-				c, err := websocket.Accept(w, r, wsOpts)
-				if err != nil {
+				token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+				switch err := room.HandleAction(r.Context(), token, note); err {
+				case nil:
+					responder.Respond(w, responder.Status(http.StatusNoContent))
+				case server.ErrInvalidToken:
+					responder.Respond(w, responder.Status(http.StatusUnauthorized))
+				case server.ErrNotConnected:
+					responder.Respond(w, responder.Status(http.StatusConflict))
+				case server.ErrRateLimited:
+					responder.Respond(w, responder.Status(http.StatusTooManyRequests))
+				default:
+					responder.Respond(w, responder.Status(http.StatusBadRequest))
+				}
+			})
+
+			r.Post("/api/room/{id}/invite", func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+
+				room := srv.FindRoomByID(chi.URLParam(r, "id"))
+				if room == nil {
+					responder.Respond(w, responder.Status(http.StatusNotFound))
 					return
 				}
 
-				g.Go(func() error {
-					room.HandleConn(ctx, query.Nickname, c)
-					return nil
-				})
+				req := &protocol.InviteRequest{}
+				body := http.MaxBytesReader(w, r.Body, protocol.MaxNoteSize)
+				if err := json.NewDecoder(body).Decode(req); err != nil || req.Expires < 0 {
+					responder.Respond(w, responder.Status(http.StatusBadRequest))
+					return
+				}
+
+				token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+				tok, expires, err := room.CreateInvite(token, req)
+				switch err {
+				case nil:
+					responder.Respond(w, responder.Body(&protocol.InviteResponse{
+						Token:   tok,
+						Expires: expires,
+					}))
+				case server.ErrInvalidToken:
+					responder.Respond(w, responder.Status(http.StatusUnauthorized))
+				case server.ErrNotConnected:
+					responder.Respond(w, responder.Status(http.StatusConflict))
+				case server.ErrNotHost:
+					responder.Respond(w, responder.Status(http.StatusForbidden))
+				case server.ErrTooManyInvites:
+					responder.Respond(w, responder.Status(http.StatusTooManyRequests))
+				default:
+					responder.Respond(w, responder.Status(http.StatusBadRequest))
+				}
 			})
 		})
 	})
@@ -259,6 +459,45 @@ func staticHandler() http.Handler {
 	return r
 }
 
+// clientAddr returns the IP address of the client which sent a request.
+func clientAddr(r *http.Request) string {
+	// middleware.RealIP replaces the address without a port.
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// parseConnQuery parses and checks the query of a request to connect to a
+// room, which is found by its ID or the query's invite, returning a nil room
+// after responding if it's invalid.
+func parseConnQuery(w http.ResponseWriter, r *http.Request, srv *server.Server) (*protocol.WSQuery, *server.Room) {
+	query := &protocol.WSQuery{}
+	if err := queryparam.Parse(r.URL.Query(), query); err != nil {
+		responder.Respond(w, responder.Status(http.StatusBadRequest))
+		return nil, nil
+	}
+
+	if _, valid := query.Valid(args.Server.MaxNickname); !valid {
+		responder.Respond(w, responder.Status(http.StatusBadRequest))
+		return nil, nil
+	}
+
+	var room *server.Room
+	if query.RoomID != "" {
+		room = srv.FindRoomByID(query.RoomID)
+	} else {
+		room = srv.FindInvite(query.Invite)
+	}
+	if room == nil {
+		responder.Respond(w, responder.Status(http.StatusBadRequest))
+		return nil, nil
+	}
+
+	return query, room
+}
+
 func checkVersion(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		want := version.Version()
@@ -286,6 +525,14 @@ func checkVersion(next http.Handler) http.Handler {
 			return
 		}
 
+		// Event streams can't see the status, so they get a close event instead.
+		if r.Header.Get("Accept") == "text/event-stream" {
+			b, _ := easyjson.Marshal(&protocol.CloseEvent{Code: 4418, Reason: reason})
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprintf(w, "event: close\ndata: %s\n\n", b)
+			return
+		}
+
 		w.WriteHeader(http.StatusTeapot)
 		fmt.Fprint(w, reason)
 	})
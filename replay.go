@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/zikaeroh/codies/internal/game"
+)
+
+// checkReplays re-simulates each replay file, checking that it reaches the
+// final state recorded in the replay.
+func checkReplays(files []string) error {
+	if len(files) == 0 {
+		return errors.New("usage: codies replay <file> [<file> ...]")
+	}
+
+	failed := 0
+
+	for _, file := range files {
+		if err := checkReplay(file); err != nil {
+			fmt.Printf("%s: %v\n", file, err)
+			failed++
+			continue
+		}
+		fmt.Printf("%s: ok\n", file)
+	}
+
+	if failed != 0 {
+		return fmt.Errorf("%d of %d replays failed", failed, len(files))
+	}
+
+	return nil
+}
+
+func checkReplay(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	replay := &game.Replay{}
+	if err := json.NewDecoder(f).Decode(replay); err != nil {
+		return err
+	}
+
+	_, err = game.Play(replay)
+	return err
+}